@@ -0,0 +1,58 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Applier changes accounts. It is implemented by client.AccountService.
+type Applier interface {
+	Create(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error)
+	UpdateClearing(ctx context.Context, account *models.Account, attributes []string) (*models.Account, *client.Response, error)
+	DeleteVersion(ctx context.Context, id string, version int) (*client.Response, error)
+}
+
+// ChangeError is returned by Apply when a change fails. Updates and deletes fail with version conflict
+// when account was changed after the plan was made, in which case the plan should be made again.
+type ChangeError struct {
+	Change Change
+	Err    error
+}
+
+// Error is required to be implemented to meet error interface
+func (e *ChangeError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Change.Action, e.Change.ID(), e.Err)
+}
+
+// Unwrap returns error of the failed change.
+func (e *ChangeError) Unwrap() error {
+	return e.Err
+}
+
+// Apply executes changes in order, updating and deleting the versions seen while planning. Attributes
+// removed from desired state are removed from updated accounts.
+// It stops at the first failed change and returns number of applied changes.
+func Apply(ctx context.Context, applier Applier, plan *Plan) (int, error) {
+	for i, c := range plan.Changes {
+		var err error
+		switch c.Action {
+		case ActionCreate:
+			_, _, err = applier.Create(ctx, c.Desired)
+		case ActionUpdate:
+			account := *c.Desired
+			account.Version = c.Live.Version
+			_, _, err = applier.UpdateClearing(ctx, &account, c.Removed())
+		case ActionDelete:
+			_, err = applier.DeleteVersion(ctx, c.Live.ID, c.Live.Version)
+		default:
+			err = fmt.Errorf("unknown action %q", c.Action)
+		}
+		if err != nil {
+			return i, &ChangeError{Change: c, Err: err}
+		}
+	}
+	return len(plan.Changes), nil
+}
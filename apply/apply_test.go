@@ -0,0 +1,79 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/clienttest"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	api := clienttest.NewFake(testAccount(testAccountB, "BARCGB22", 2), testAccount(testAccountC, "", 0))
+	state := &State{
+		OrganisationID: testOrganisationID,
+		Accounts: []models.Account{
+			testAccount(testAccountA, "", 0),
+			testAccount(testAccountB, "NWBKGB22", 0),
+		},
+	}
+	planner := NewPlanner(api)
+	planner.Prune = true
+	plan, err := planner.Plan(context.TODO(), state)
+	require.Nil(t, err)
+
+	applied, err := Apply(context.TODO(), api, plan)
+	require.Nil(t, err)
+	assert.Equal(t, 3, applied)
+
+	accounts := api.Accounts()
+	require.Len(t, accounts, 2)
+	assert.Equal(t, testAccountA, accounts[0].ID)
+	assert.Equal(t, "NWBKGB22", accounts[1].Attributes.Bic)
+	assert.Equal(t, 3, accounts[1].Version)
+
+	plan, err = planner.Plan(context.TODO(), state)
+	require.Nil(t, err)
+	assert.True(t, plan.Empty())
+}
+
+func TestApply_RemovedAttributes(t *testing.T) {
+	api := clienttest.NewFake(testAccount(testAccountA, "BARCGB22", 2))
+	state := &State{Accounts: []models.Account{testAccount(testAccountA, "", 0)}}
+	planner := NewPlanner(api)
+	plan, err := planner.Plan(context.TODO(), state)
+	require.Nil(t, err)
+
+	applied, err := Apply(context.TODO(), api, plan)
+	require.Nil(t, err)
+	assert.Equal(t, 1, applied)
+	assert.Empty(t, api.Accounts()[0].Attributes.Bic)
+
+	plan, err = planner.Plan(context.TODO(), state)
+	require.Nil(t, err)
+	assert.True(t, plan.Empty(), "it should converge once removed attributes are cleared")
+}
+
+func TestApply_VersionConflict(t *testing.T) {
+	api := clienttest.NewFake(testAccount(testAccountB, "BARCGB22", 2))
+	state := &State{Accounts: []models.Account{testAccount(testAccountB, "NWBKGB22", 0)}}
+	plan, err := NewPlanner(api).Plan(context.TODO(), state)
+	require.Nil(t, err)
+
+	changed := testAccount(testAccountB, "HBUKGB4B", 2)
+	_, _, err = api.Update(context.TODO(), &changed)
+	require.Nil(t, err)
+
+	applied, err := Apply(context.TODO(), api, plan)
+	assert.Equal(t, 0, applied)
+	assert.EqualError(t, err, "update "+testAccountB+": code: 409, message: invalid version")
+
+	var errResp *client.ErrorResponse
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, http.StatusConflict, errResp.StatusCode)
+}
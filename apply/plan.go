@@ -0,0 +1,257 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/rhymond/interview-accountapi/reconcile"
+)
+
+// Action is a change applied to a single account.
+type Action string
+
+// Actions of a plan.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a planned change of a single account.
+type Change struct {
+	Action Action
+	// Desired is the account as described in state, nil for deletes.
+	Desired *models.Account
+	// Live is the account as held by the API when planning, nil for creates.
+	Live *models.Account
+	// Differences lists fields changed by an update. Expected value of attributes removed from desired
+	// state is nil.
+	Differences []reconcile.Difference
+}
+
+// Removed returns names of attributes removed by an update.
+func (c *Change) Removed() []string {
+	var removed []string
+	for _, d := range c.Differences {
+		if d.Expected == nil {
+			removed = append(removed, d.Field)
+		}
+	}
+	return removed
+}
+
+// ID returns ID of changed account.
+func (c *Change) ID() string {
+	if c.Desired != nil {
+		return c.Desired.ID
+	}
+	return c.Live.ID
+}
+
+// Plan lists changes needed to reach desired state, ordered by action and account ID.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether accounts already match desired state.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns number of changes with given action.
+func (p *Plan) Count(action Action) int {
+	count := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Reader reads live accounts. It is implemented by client.AccountService.
+type Reader interface {
+	Fetch(ctx context.Context, id string) (*models.Account, *client.Response, error)
+	ListAll(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) error
+}
+
+// Planner compares desired state with accounts held by the API.
+type Planner struct {
+	reader Reader
+
+	// Prune plans deletes of live accounts missing in desired state. Live accounts are limited by
+	// Filter and by organisation of the state, if it is set.
+	Prune bool
+	// Filter limits live accounts considered for deletion. Desired accounts are looked up regardless of it.
+	Filter *client.AccountFilter
+}
+
+// NewPlanner creates Planner reading live accounts using given reader.
+func NewPlanner(reader Reader) *Planner {
+	return &Planner{reader: reader}
+}
+
+// Plan lists live accounts and plans creates of missing accounts, updates of accounts which differ
+// from desired state, including attributes missing in it, and, if pruning, deletes of accounts which are not desired. Desired accounts
+// which are not listed, e.g. because they do not match Filter, are fetched by ID.
+func (p *Planner) Plan(ctx context.Context, state *State) (*Plan, error) {
+	desired := make(map[string]bool, len(state.Accounts))
+	for _, acc := range state.Accounts {
+		desired[acc.ID] = true
+	}
+
+	filter := &client.AccountFilter{}
+	if p.Filter != nil {
+		*filter = *p.Filter
+	}
+	if state.OrganisationID != "" {
+		filter.OrganisationID = state.OrganisationID
+	}
+
+	plan := &Plan{}
+	live := make(map[string]*models.Account)
+	err := p.reader.ListAll(ctx, &client.AccountListOptions{Filter: filter}, func(acc *models.Account) error {
+		current := *acc
+		if desired[acc.ID] {
+			live[acc.ID] = &current
+		} else if p.Prune {
+			plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Live: &current})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acc := range state.Accounts {
+		want := acc
+		current, ok := live[acc.ID]
+		if !ok {
+			if current, err = p.fetch(ctx, acc.ID); err != nil {
+				return nil, err
+			}
+		}
+		if current == nil {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Desired: &want})
+			continue
+		}
+
+		diffs, err := compare(&want, current)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) > 0 {
+			plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, Desired: &want, Live: current, Differences: diffs})
+		}
+	}
+
+	order := map[Action]int{ActionCreate: 0, ActionUpdate: 1, ActionDelete: 2}
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		a, b := plan.Changes[i], plan.Changes[j]
+		if a.Action != b.Action {
+			return order[a.Action] < order[b.Action]
+		}
+		return a.ID() < b.ID()
+	})
+	return plan, nil
+}
+
+// statusAttributes are changed by status transitions, e.g. client.AccountService.Close, so they are
+// not removed from live accounts when missing in desired state.
+var statusAttributes = map[string]bool{
+	"status":                   true,
+	"status_reason":            true,
+	"switched_account_details": true,
+}
+
+// compare returns differences of fields set in desired account, followed by attributes set on live account,
+// but missing in desired one, ordered by field name.
+func compare(desired, live *models.Account) ([]reconcile.Difference, error) {
+	diffs, err := reconcile.Compare(desired, live)
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := attributes(desired)
+	if err != nil {
+		return nil, err
+	}
+	got, err := attributes(live)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range got {
+		if _, ok := want[name]; !ok && !statusAttributes[name] {
+			diffs = append(diffs, reconcile.Difference{Field: name, Expected: nil, Actual: value})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+	return diffs, nil
+}
+
+// attributes decodes attributes of account which are set, keyed by JSON field names.
+func attributes(acc *models.Account) (map[string]interface{}, error) {
+	data, err := json.Marshal(acc.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	var attrs map[string]interface{}
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// fetch returns live account with given ID, or nil if it does not exist.
+func (p *Planner) fetch(ctx context.Context, id string) (*models.Account, error) {
+	acc, _, err := p.reader.Fetch(ctx, id)
+	var errResp *client.ErrorResponse
+	if errors.As(err, &errResp) && errResp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return acc, err
+}
+
+// Write prints plan in human readable form, listing changed fields of updated accounts.
+func (p *Plan) Write(w io.Writer) error {
+	if p.Empty() {
+		_, err := fmt.Fprintln(w, "no changes, accounts match desired state")
+		return err
+	}
+
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			fmt.Fprintf(w, "+ create %s\n", c.ID())
+		case ActionUpdate:
+			fmt.Fprintf(w, "~ update %s (version %d)\n", c.ID(), c.Live.Version)
+			for _, d := range c.Differences {
+				fmt.Fprintf(w, "    %s: %s -> %s\n", d.Field, formatValue(d.Actual), formatValue(d.Expected))
+			}
+		case ActionDelete:
+			fmt.Fprintf(w, "- delete %s (version %d)\n", c.ID(), c.Live.Version)
+		}
+	}
+	_, err := fmt.Fprintf(w, "plan: %d to create, %d to update, %d to delete\n",
+		p.Count(ActionCreate), p.Count(ActionUpdate), p.Count(ActionDelete))
+	return err
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,144 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/clienttest"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccount(id, bic string, version int) models.Account {
+	return models.Account{
+		ID:             id,
+		OrganisationID: testOrganisationID,
+		Type:           "accounts",
+		Version:        version,
+		Attributes:     models.AccountAttributes{Country: "GB", Bic: bic},
+	}
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	state := &State{
+		OrganisationID: testOrganisationID,
+		Accounts: []models.Account{
+			testAccount(testAccountB, "NWBKGB22", 0),
+			testAccount(testAccountA, "", 0),
+		},
+	}
+	tests := []struct {
+		name            string
+		givenPrune      bool
+		expectedActions []Action
+		expectedIDs     []string
+		expectedOutput  string
+	}{
+		{
+			name:            "it should plan creates and updates",
+			givenPrune:      false,
+			expectedActions: []Action{ActionCreate, ActionUpdate},
+			expectedIDs:     []string{testAccountA, testAccountB},
+			expectedOutput: "+ create " + testAccountA + "\n" +
+				"~ update " + testAccountB + " (version 2)\n" +
+				"    bic: \"BARCGB22\" -> \"NWBKGB22\"\n" +
+				"plan: 1 to create, 1 to update, 0 to delete\n",
+		},
+		{
+			name:            "it should plan deletes when pruning",
+			givenPrune:      true,
+			expectedActions: []Action{ActionCreate, ActionUpdate, ActionDelete},
+			expectedIDs:     []string{testAccountA, testAccountB, testAccountC},
+			expectedOutput: "+ create " + testAccountA + "\n" +
+				"~ update " + testAccountB + " (version 2)\n" +
+				"    bic: \"BARCGB22\" -> \"NWBKGB22\"\n" +
+				"- delete " + testAccountC + " (version 0)\n" +
+				"plan: 1 to create, 1 to update, 1 to delete\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			other := testAccount("other", "", 0)
+			other.OrganisationID = "other-organisation"
+			api := clienttest.NewFake(testAccount(testAccountB, "BARCGB22", 2), testAccount(testAccountC, "", 0), other)
+
+			planner := NewPlanner(api)
+			planner.Prune = test.givenPrune
+			plan, err := planner.Plan(context.TODO(), state)
+			require.Nil(t, err)
+
+			var actions []Action
+			var ids []string
+			for _, c := range plan.Changes {
+				actions = append(actions, c.Action)
+				ids = append(ids, c.ID())
+			}
+			assert.Equal(t, test.expectedActions, actions)
+			assert.Equal(t, test.expectedIDs, ids)
+
+			buf := &bytes.Buffer{}
+			require.Nil(t, plan.Write(buf))
+			assert.Equal(t, test.expectedOutput, buf.String())
+		})
+	}
+}
+
+func TestPlanner_PlanFilter(t *testing.T) {
+	fr := testAccount(testAccountC, "", 0)
+	fr.Attributes.Country = "FR"
+	api := clienttest.NewFake(testAccount(testAccountA, "", 0), testAccount(testAccountB, "BARCGB22", 2), fr)
+	state := &State{
+		OrganisationID: testOrganisationID,
+		Accounts:       []models.Account{testAccount(testAccountB, "NWBKGB22", 0)},
+	}
+
+	planner := NewPlanner(api)
+	planner.Prune = true
+	planner.Filter = &client.AccountFilter{Country: "FR"}
+	plan, err := planner.Plan(context.TODO(), state)
+	require.Nil(t, err)
+
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, ActionUpdate, plan.Changes[0].Action, "it should update desired account outside of filter")
+	assert.Equal(t, testAccountB, plan.Changes[0].ID())
+	assert.Equal(t, 2, plan.Changes[0].Live.Version)
+	assert.Equal(t, ActionDelete, plan.Changes[1].Action, "it should delete only accounts matching filter")
+	assert.Equal(t, testAccountC, plan.Changes[1].ID())
+}
+
+func TestPlanner_PlanRemovedAttributes(t *testing.T) {
+	live := testAccount(testAccountA, "BARCGB22", 2)
+	live.Attributes.Status = models.AccountClosed
+	live.Attributes.StatusReason = "customer request"
+	api := clienttest.NewFake(live)
+	state := &State{Accounts: []models.Account{testAccount(testAccountA, "", 0)}}
+
+	plan, err := NewPlanner(api).Plan(context.TODO(), state)
+	require.Nil(t, err)
+
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, ActionUpdate, plan.Changes[0].Action)
+	assert.Equal(t, []string{"bic"}, plan.Changes[0].Removed(), "it should not remove status attributes")
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, plan.Write(buf))
+	assert.Equal(t, "~ update "+testAccountA+" (version 2)\n"+
+		"    bic: \"BARCGB22\" -> (none)\n"+
+		"plan: 0 to create, 1 to update, 0 to delete\n", buf.String())
+}
+
+func TestPlanner_PlanNoChanges(t *testing.T) {
+	api := clienttest.NewFake(testAccount(testAccountA, "", 3))
+	state := &State{Accounts: []models.Account{testAccount(testAccountA, "", 0)}}
+
+	plan, err := NewPlanner(api).Plan(context.TODO(), state)
+	require.Nil(t, err)
+	assert.True(t, plan.Empty())
+
+	buf := &bytes.Buffer{}
+	require.Nil(t, plan.Write(buf))
+	assert.Equal(t, "no changes, accounts match desired state\n", buf.String())
+}
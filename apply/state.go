@@ -0,0 +1,130 @@
+// Package apply manages accounts as code: it plans and applies changes needed to make accounts held
+// by the API match desired state described in a YAML or JSON file.
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/rhymond/interview-accountapi/importer"
+	"github.com/rhymond/interview-accountapi/models"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// State is desired set of accounts. Accounts use the same field names as the JSON API.
+//
+//	organisation_id: 743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb
+//	accounts:
+//	  - id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc
+//	    attributes:
+//	      country: GB
+//	      bank_id: "400300"
+type State struct {
+	// OrganisationID is used for accounts which do not specify organisation. When set, only accounts
+	// of this organisation are considered when planning deletes.
+	OrganisationID string           `json:"organisation_id"`
+	Accounts       []models.Account `json:"accounts"`
+}
+
+// Decode reads state in given format, yaml or json, and validates it.
+func Decode(r io.Reader, format string) (*State, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "json":
+	case "yaml":
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown state format %q", format)
+	}
+
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if err := state.normalise(); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Format returns state format of file based on its extension. Files which are not JSON are read as YAML.
+func Format(file string) string {
+	if strings.ToLower(filepath.Ext(file)) == ".json" {
+		return "json"
+	}
+	return "yaml"
+}
+
+// normalise fills in defaults and validates all accounts.
+func (s *State) normalise() error {
+	seen := make(map[string]bool, len(s.Accounts))
+	for i := range s.Accounts {
+		acc := &s.Accounts[i]
+		if acc.OrganisationID == "" {
+			acc.OrganisationID = s.OrganisationID
+		}
+		if acc.Type == "" {
+			acc.Type = "accounts"
+		}
+		if err := importer.Validate(acc); err != nil {
+			return fmt.Errorf("account %d: %v", i+1, err)
+		}
+		if seen[acc.ID] {
+			return fmt.Errorf("account %d: duplicate id %s", i+1, acc.ID)
+		}
+		seen[acc.ID] = true
+	}
+	return nil
+}
+
+// yamlToJSON converts YAML document to JSON, so it can be decoded using JSON field names.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	converted, err := stringKeys(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(converted)
+}
+
+// stringKeys replaces maps with interface keys produced by yaml.v2 with maps JSON can encode.
+func stringKeys(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported key %v", key)
+			}
+			converted, err := stringKeys(value)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case []interface{}:
+		for i, value := range v {
+			converted, err := stringKeys(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,93 @@
+package apply
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOrganisationID = "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+	testAccountA       = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	testAccountB       = "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	testAccountC       = "cd27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenFormat   string
+		givenState    string
+		expectedError string
+	}{
+		{
+			name:        "it should decode yaml state",
+			givenFormat: "yaml",
+			givenState: `
+organisation_id: 743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb
+accounts:
+  - id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc
+    attributes:
+      country: GB
+      bank_id: "400300"
+      alternative_bank_account_names: [Sam]
+`,
+		},
+		{
+			name:        "it should decode json state",
+			givenFormat: "json",
+			givenState: `{"organisation_id":"743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb","accounts":[
+				{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","attributes":{"country":"GB","bank_id":"400300","alternative_bank_account_names":["Sam"]}}]}`,
+		},
+		{
+			name:        "it should reject invalid account",
+			givenFormat: "yaml",
+			givenState: `
+accounts:
+  - id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc
+    attributes: {country: GB}
+`,
+			expectedError: "account 1: organisation_id must be a valid UUID",
+		},
+		{
+			name:        "it should reject duplicate ids",
+			givenFormat: "yaml",
+			givenState: `
+organisation_id: 743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb
+accounts:
+  - {id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc, attributes: {country: GB}}
+  - {id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc, attributes: {country: FR}}
+`,
+			expectedError: "account 2: duplicate id ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		},
+		{
+			name:          "it should reject unknown format",
+			givenFormat:   "toml",
+			expectedError: `unknown state format "toml"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, err := Decode(strings.NewReader(test.givenState), test.givenFormat)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.Nil(t, err)
+			require.Len(t, state.Accounts, 1)
+
+			acc := state.Accounts[0]
+			assert.Equal(t, testOrganisationID, acc.OrganisationID)
+			assert.Equal(t, "accounts", acc.Type)
+			assert.Equal(t, "400300", acc.Attributes.BankID)
+			assert.Equal(t, []string{"Sam"}, acc.Attributes.AlternativeBankAccountNames)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "json", Format("accounts.JSON"))
+	assert.Equal(t, "yaml", Format("accounts.yml"))
+}
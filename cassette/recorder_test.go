@@ -0,0 +1,133 @@
+package cassette
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tempCassette(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "cassette")
+	require.Nil(t, err)
+	return filepath.Join(dir, "fixtures", "accounts.json"), func() { os.RemoveAll(dir) }
+}
+
+func newAccountClient(baseURL string, transport http.RoundTripper) *client.Client {
+	u, _ := url.Parse(baseURL)
+	return client.NewClient(&http.Client{Transport: transport}, u)
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":"account-id","version":0,"attributes":{"country":"GB","iban":"GB28NWBK40030212764204"}}}`)
+		case http.MethodGet:
+			fetches++
+			if fetches > 1 {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error_message":"record account-id does not exist"}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"id":"account-id","version":0,"attributes":{"country":"GB"}}}`)
+		}
+	}))
+
+	rec, err := New(path, ModeRecord, nil)
+	require.Nil(t, err)
+	c := newAccountClient(server.URL, rec)
+	account := &models.Account{ID: "account-id", Attributes: models.AccountAttributes{Country: "GB", AccountNumber: "12345678"}}
+	_, _, err = c.Account.Create(context.TODO(), account)
+	require.Nil(t, err)
+	_, _, err = c.Account.Fetch(context.TODO(), "account-id")
+	require.Nil(t, err)
+	_, _, err = c.Account.Fetch(context.TODO(), "account-id")
+	require.NotNil(t, err)
+	require.Nil(t, rec.Stop())
+	server.Close()
+
+	saved, err := Load(path)
+	require.Nil(t, err)
+	require.Len(t, saved.Interactions, 3)
+	assert.Contains(t, saved.Interactions[0].Request.Body, `"account_number":"REDACTED"`)
+	assert.Contains(t, saved.Interactions[0].Response.Body, `"iban":"REDACTED"`)
+	assert.Equal(t, "/v1/organisation/accounts/account-id", saved.Interactions[1].Request.URL)
+
+	// replay against address where nothing listens
+	rec, err = New(path, ModeReplay, nil)
+	require.Nil(t, err)
+	c = newAccountClient("http://127.0.0.1:1", rec)
+
+	created, _, err := c.Account.Create(context.TODO(), account)
+	require.Nil(t, err)
+	assert.Equal(t, "REDACTED", created.Attributes.Iban)
+
+	fetched, resp, err := c.Account.Fetch(context.TODO(), "account-id")
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.Response.StatusCode)
+	assert.Equal(t, "account-id", fetched.ID)
+
+	_, _, err = c.Account.Fetch(context.TODO(), "account-id")
+	assert.EqualError(t, err, "code: 404, message: record account-id does not exist")
+	assert.Empty(t, rec.Unused())
+
+	_, _, err = c.Account.Fetch(context.TODO(), "account-id")
+	assert.Contains(t, err.Error(), "no recorded interaction for GET /v1/organisation/accounts/account-id")
+}
+
+func TestRecorder_ReplayMatchesNormalisedQuery(t *testing.T) {
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+
+	c := &Cassette{Interactions: []Interaction{
+		{
+			Request:  Request{Method: http.MethodGet, URL: "/v1/organisation/accounts?page%5Bnumber%5D=1&page%5Bsize%5D=2"},
+			Response: Response{StatusCode: http.StatusOK, Body: `{"data":[{"id":"a"}]}`},
+		},
+		{
+			Request:  Request{Method: http.MethodGet, URL: "/v1/organisation/accounts"},
+			Response: Response{StatusCode: http.StatusOK, Body: `{"data":[]}`},
+		},
+	}}
+	require.Nil(t, c.Save(path))
+
+	rec, err := New(path, ModeReplay, nil)
+	require.Nil(t, err)
+	accounts, _, err := newAccountClient("http://example.com", rec).Account.ListWithOptions(context.TODO(), &client.AccountListOptions{
+		Pagination: client.Pagination{Page: 1, PerPage: 2},
+	})
+	require.Nil(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, "a", accounts[0].ID)
+	assert.Len(t, rec.Unused(), 1)
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	_, err := New("does-not-exist.json", ModeReplay, nil)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("Record")
+	require.Nil(t, err)
+	assert.Equal(t, ModeRecord, mode)
+
+	_, err = ParseMode("rewind")
+	assert.EqualError(t, err, `unknown cassette mode "rewind"`)
+}
@@ -0,0 +1,100 @@
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Redacted replaces values of sensitive headers and fields in recorded cassettes.
+const Redacted = "REDACTED"
+
+// Redaction describes which headers and JSON fields are replaced before interactions are saved.
+type Redaction struct {
+	// Headers are names of headers to redact.
+	Headers []string
+	// Fields are JSON object keys to redact at any depth of request and response bodies.
+	Fields []string
+}
+
+// DefaultRedaction redacts credentials and personal data of account holders.
+var DefaultRedaction = Redaction{
+	Headers: []string{"Authorization", "Cookie", "Set-Cookie"},
+	Fields: []string{
+		"account_number",
+		"iban",
+		"title",
+		"first_name",
+		"bank_account_name",
+		"alternative_bank_account_names",
+		"secondary_identification",
+	},
+}
+
+func (r Redaction) header(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	h = h.Clone()
+	for _, name := range r.Headers {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// body redacts fields of JSON body. Bodies which are not JSON are returned unchanged.
+func (r Redaction) body(body []byte) string {
+	if len(body) == 0 || len(r.Fields) == 0 {
+		return string(body)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return string(body)
+	}
+
+	fields := make(map[string]bool, len(r.Fields))
+	for _, f := range r.Fields {
+		fields[f] = true
+	}
+
+	redacted, err := json.Marshal(redactValue(doc, fields))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if fields[key] {
+				v[key] = redactLeaf(value)
+				continue
+			}
+			v[key] = redactValue(value, fields)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value, fields)
+		}
+	}
+	return v
+}
+
+// redactLeaf replaces strings, keeping the shape of lists so decoding into typed models still works.
+func redactLeaf(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return Redacted
+	case []interface{}:
+		for i := range v {
+			v[i] = redactLeaf(v[i])
+		}
+		return v
+	}
+	return v
+}
@@ -0,0 +1,47 @@
+package cassette
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedaction_Body(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenBody    string
+		expectedBody string
+	}{
+		{
+			name:         "it should redact nested fields and keep list shape",
+			givenBody:    `{"data":[{"id":"a","attributes":{"iban":"GB28","alternative_bank_account_names":["Jane","J"]}}]}`,
+			expectedBody: `{"data":[{"attributes":{"alternative_bank_account_names":["REDACTED","REDACTED"],"iban":"REDACTED"},"id":"a"}]}`,
+		},
+		{
+			name:         "it should leave body which is not JSON unchanged",
+			givenBody:    `iban=GB28`,
+			expectedBody: `iban=GB28`,
+		},
+		{
+			name:         "it should leave empty body unchanged",
+			givenBody:    ``,
+			expectedBody: ``,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedBody, DefaultRedaction.body([]byte(test.givenBody)))
+		})
+	}
+}
+
+func TestRedaction_Header(t *testing.T) {
+	h := http.Header{"Authorization": []string{"Bearer secret"}, "Accept": []string{"application/json"}}
+	redacted := DefaultRedaction.header(h)
+
+	assert.Equal(t, Redacted, redacted.Get("Authorization"))
+	assert.Equal(t, "application/json", redacted.Get("Accept"))
+	assert.Equal(t, "Bearer secret", h.Get("Authorization"), "it should not modify given headers")
+	assert.Nil(t, DefaultRedaction.header(nil))
+}
@@ -0,0 +1,212 @@
+// Package chaos provides http.RoundTripper injecting faults into account API traffic,
+// so resilience of its consumers can be tested with reproducible failures.
+package chaos
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrConnectionDropped is returned for requests whose connection was dropped by the transport.
+var ErrConnectionDropped = errors.New("chaos: connection dropped")
+
+// Fault is a kind of injected failure.
+type Fault string
+
+// Injected faults.
+const (
+	FaultNone        Fault = "none"
+	FaultDrop        Fault = "drop"
+	FaultServerError Fault = "server_error"
+	FaultRateLimit   Fault = "rate_limit"
+	FaultTruncate    Fault = "truncate"
+	FaultMalformed   Fault = "malformed"
+)
+
+// Config sets rates of injected faults. Rates are probabilities between 0 and 1, evaluated for every request.
+// Drop, server error and rate limit faults are decided before the request is sent and the request never
+// reaches the API; truncate and malformed faults alter real responses.
+type Config struct {
+	// Latency is added to every request. Jitter adds up to given random duration on top of it.
+	Latency time.Duration
+	Jitter  time.Duration
+
+	DropRate        float64
+	ServerErrorRate float64
+	RateLimitRate   float64
+	TruncateRate    float64
+	MalformedRate   float64
+
+	// ServerErrorStatus is status code of injected server errors, 503 by default.
+	ServerErrorStatus int
+	// RetryAfter is value of Retry-After header of injected rate limit responses, 1 second by default.
+	RetryAfter time.Duration
+
+	// Seed makes injected faults reproducible: the same seed and sequence of requests give the same faults.
+	Seed int64
+}
+
+// Transport is fault injecting http.RoundTripper. It is safe for concurrent use, but faults are only
+// reproducible when requests are sent sequentially.
+type Transport struct {
+	transport http.RoundTripper
+	cfg       Config
+
+	mu     sync.Mutex
+	rng    *rand.Rand
+	faults map[Fault]int
+}
+
+// New creates Transport sending requests which are not failed using transport, http.DefaultTransport if nil.
+func New(transport http.RoundTripper, cfg Config) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if cfg.ServerErrorStatus == 0 {
+		cfg.ServerErrorStatus = http.StatusServiceUnavailable
+	}
+	if cfg.RetryAfter == 0 {
+		cfg.RetryAfter = time.Second
+	}
+	return &Transport{
+		transport: transport,
+		cfg:       cfg,
+		rng:       rand.New(rand.NewSource(cfg.Seed)),
+		faults:    make(map[Fault]int),
+	}
+}
+
+// Faults returns how many times every fault was injected. Requests passed through without faults
+// are not counted.
+func (t *Transport) Faults() map[Fault]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	faults := make(map[Fault]int, len(t.faults))
+	for f, n := range t.faults {
+		faults[f] = n
+	}
+	return faults
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay, before, after := t.roll()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	switch before {
+	case FaultDrop:
+		closeBody(req)
+		return nil, ErrConnectionDropped
+	case FaultServerError:
+		closeBody(req)
+		return newResponse(req, t.cfg.ServerErrorStatus, nil, `{"error_message":"chaos: injected server error"}`), nil
+	case FaultRateLimit:
+		closeBody(req)
+		header := http.Header{}
+		header.Set("Retry-After", fmt.Sprintf("%d", int(t.cfg.RetryAfter/time.Second)))
+		header.Set("X-Ratelimit-Remaining", "0")
+		return newResponse(req, http.StatusTooManyRequests, header, `{"error_message":"chaos: injected rate limit"}`), nil
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || after == FaultNone {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	switch after {
+	case FaultTruncate:
+		body = body[:len(body)/2]
+	case FaultMalformed:
+		body = []byte(`{"data": [{"id": "chaos"`)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// roll decides latency and faults of a single request. Random numbers are always drawn in the same
+// order, so the sequence of faults depends only on the seed and number of requests.
+func (t *Transport) roll() (time.Duration, Fault, Fault) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delay := t.cfg.Latency
+	jitter := t.rng.Float64()
+	if t.cfg.Jitter > 0 {
+		delay += time.Duration(jitter * float64(t.cfg.Jitter))
+	}
+
+	before := pick(t.rng.Float64(), []Fault{FaultDrop, FaultServerError, FaultRateLimit},
+		[]float64{t.cfg.DropRate, t.cfg.ServerErrorRate, t.cfg.RateLimitRate})
+	after := pick(t.rng.Float64(), []Fault{FaultTruncate, FaultMalformed},
+		[]float64{t.cfg.TruncateRate, t.cfg.MalformedRate})
+	if before != FaultNone {
+		t.faults[before]++
+		return delay, before, FaultNone
+	}
+
+	if after != FaultNone {
+		t.faults[after]++
+	}
+	return delay, before, after
+}
+
+// pick selects a fault for drawn number using cumulative rates.
+func pick(n float64, faults []Fault, rates []float64) Fault {
+	threshold := 0.0
+	for i, f := range faults {
+		threshold += rates[i]
+		if n < threshold {
+			return f
+		}
+	}
+	return FaultNone
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+func newResponse(req *http.Request, status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/vnd.api+json")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
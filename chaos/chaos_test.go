@@ -0,0 +1,152 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const accountResponse = `{"data":{"id":"account-id","type":"accounts","version":0,"attributes":{"country":"GB"}}}`
+
+func newChaosClient(t *testing.T, cfg Config) (*client.Client, *Transport, *int, func()) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, accountResponse)
+	}))
+	transport := New(nil, cfg)
+	u, _ := url.Parse(server.URL)
+	return client.NewClient(&http.Client{Transport: transport}, u), transport, &calls, server.Close
+}
+
+func TestTransport_Faults(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenConfig    Config
+		expectedFaults map[Fault]int
+		expectedCalls  int
+		expectedError  string
+	}{
+		{
+			name:           "it should pass requests through without faults",
+			givenConfig:    Config{},
+			expectedFaults: map[Fault]int{},
+			expectedCalls:  1,
+		},
+		{
+			name:           "it should drop connection",
+			givenConfig:    Config{DropRate: 1},
+			expectedFaults: map[Fault]int{FaultDrop: 1},
+			expectedError:  "chaos: connection dropped",
+		},
+		{
+			name:           "it should return server error",
+			givenConfig:    Config{ServerErrorRate: 1, ServerErrorStatus: http.StatusBadGateway},
+			expectedFaults: map[Fault]int{FaultServerError: 1},
+			expectedError:  "code: 502, message: chaos: injected server error",
+		},
+		{
+			name:           "it should return rate limit error",
+			givenConfig:    Config{RateLimitRate: 1},
+			expectedFaults: map[Fault]int{FaultRateLimit: 1},
+			expectedError:  "code: 429, message: chaos: injected rate limit",
+		},
+		{
+			name:           "it should truncate response body",
+			givenConfig:    Config{TruncateRate: 1},
+			expectedFaults: map[Fault]int{FaultTruncate: 1},
+			expectedCalls:  1,
+			expectedError:  "unexpected end of JSON input",
+		},
+		{
+			name:           "it should return malformed response body",
+			givenConfig:    Config{MalformedRate: 1},
+			expectedFaults: map[Fault]int{FaultMalformed: 1},
+			expectedCalls:  1,
+			expectedError:  "unexpected end of JSON input",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, transport, calls, closeServer := newChaosClient(t, test.givenConfig)
+			defer closeServer()
+
+			_, _, err := c.Account.Fetch(context.TODO(), "account-id")
+			if test.expectedError == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+			}
+			assert.Equal(t, test.expectedCalls, *calls)
+			assert.Equal(t, test.expectedFaults, transport.Faults())
+		})
+	}
+}
+
+func TestTransport_RateLimitHeaders(t *testing.T) {
+	c, _, _, closeServer := newChaosClient(t, Config{RateLimitRate: 1, RetryAfter: 3 * time.Second})
+	defer closeServer()
+
+	_, _, err := c.Account.Fetch(context.TODO(), "account-id")
+	require.IsType(t, &client.ErrorResponse{}, err)
+	resp := err.(*client.ErrorResponse).Response
+	assert.Equal(t, "3", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "0", resp.Header.Get("X-Ratelimit-Remaining"))
+}
+
+func TestTransport_Reproducible(t *testing.T) {
+	cfg := Config{DropRate: 0.2, ServerErrorRate: 0.2, RateLimitRate: 0.1, TruncateRate: 0.1, MalformedRate: 0.1, Seed: 42}
+	sequence := func() []Fault {
+		transport := New(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return newResponse(req, http.StatusOK, nil, accountResponse), nil
+		}), cfg)
+
+		var faults []Fault
+		for i := 0; i < 50; i++ {
+			req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+			before := transport.Faults()
+			transport.RoundTrip(req)
+			fault := FaultNone
+			for f, n := range transport.Faults() {
+				if n != before[f] {
+					fault = f
+				}
+			}
+			faults = append(faults, fault)
+		}
+		return faults
+	}
+
+	first := sequence()
+	assert.Equal(t, first, sequence(), "it should inject the same faults for the same seed")
+	assert.Contains(t, first, FaultNone)
+	assert.Contains(t, first, FaultDrop)
+}
+
+func TestTransport_Latency(t *testing.T) {
+	c, _, _, closeServer := newChaosClient(t, Config{Latency: time.Second})
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := c.Account.Fetch(ctx, "account-id")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "context deadline exceeded")
+	assert.True(t, time.Since(start) < time.Second, "it should stop waiting when context is done")
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -2,71 +2,207 @@ package client
 
 import (
 	"context"
-	"fmt"
-	"net/http"
+	"encoding/json"
 
 	"github.com/rhymond/interview-accountapi/models"
 )
 
 // AccountService holds API functionality for accounts API.
 type AccountService struct {
-	client *Client
+	resources *ResourceService[models.Account]
+	partial   *ResourceService[models.PartialAccount]
 }
 
-// Create registers an existing bank account with Form3 or create a new one. The country attribute must be specified as a minimum. Depending on the country, other attributes such as bank_id and bic are mandatory.
-func (s *AccountService) Create(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
-	req, err := s.client.NewRequest(ctx, http.MethodPost, "v1/organisation/accounts", account)
-	acc := &models.Account{}
-	resp, err := s.client.Do(ctx, req, acc)
-	if err != nil {
-		return nil, resp, err
+// AccountFilter is a structure required to build query parameters for filtering accounts list.
+type AccountFilter struct {
+	BankIDCode    string `url:"bank_id_code,omitempty"`
+	BankID        string `url:"bank_id,omitempty"`
+	AccountNumber string `url:"account_number,omitempty"`
+	Iban          string `url:"iban,omitempty"`
+	CustomerID    string `url:"customer_id,omitempty"`
+	Country       string `url:"country,omitempty"`
+	// OrganisationID limits accounts to a single organisation.
+	OrganisationID string `url:"organisation_id,omitempty"`
+}
+
+// Relationships of accounts which can be included in responses.
+const (
+	IncludeMasterAccount = "master_account"
+	IncludeOrganisation  = "organisation"
+)
+
+// AccountListOptions specifies optional parameters for listing accounts.
+type AccountListOptions struct {
+	Pagination
+	Filter *AccountFilter `url:"filter,omitempty"`
+	// Include lists relationships whose resources should be included in the response, see Resolve.
+	Include []string `url:"include,comma,omitempty"`
+	// Fields limits returned attributes to given ones, e.g. "bank_id". All attributes are returned if it is empty.
+	// Use ListPartial to learn which attributes were returned.
+	Fields []string `url:"fields[accounts],comma,omitempty"`
+	// Sort orders accounts by given attributes. Prefix attribute with "-" to sort in descending order.
+	Sort []string `url:"sort,comma,omitempty"`
+}
+
+// paginationOptions returns list options with given pagination, or nil if it is nil.
+func paginationOptions(pagination *Pagination) *AccountListOptions {
+	if pagination == nil {
+		return nil
 	}
+	return &AccountListOptions{Pagination: *pagination}
+}
+
+// AccountFetchOptions specifies optional parameters for fetching an account.
+type AccountFetchOptions struct {
+	// Include lists relationships whose resources should be included in the response, see Resolve.
+	Include []string `url:"include,comma,omitempty"`
+}
 
-	return acc, resp, nil
+// Create registers an existing bank account with Form3 or create a new one. The country attribute must be specified as a minimum. Depending on the country, other attributes such as bank_id and bic are mandatory.
+func (s *AccountService) Create(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
+	return s.resources.Create(ctx, account)
 }
 
-// List accounts with the ability to filter and page.
+// List accounts with the ability to page.
 func (s *AccountService) List(ctx context.Context, pagination *Pagination) ([]models.Account, *Response, error) {
-	path := fmt.Sprintf("v1/organisation/accounts")
-	path, err := addOptions(path, pagination)
-	if err != nil {
-		return nil, nil, err
-	}
+	return s.ListWithOptions(ctx, paginationOptions(pagination))
+}
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
-	var accounts []models.Account
-	resp, err := s.client.Do(ctx, req, &accounts)
-	if err != nil {
-		return nil, resp, err
-	}
-	return accounts, resp, nil
+// ListWithOptions lists accounts with the ability to filter and page.
+func (s *AccountService) ListWithOptions(ctx context.Context, opts *AccountListOptions) ([]models.Account, *Response, error) {
+	return s.resources.List(ctx, opts)
+}
+
+// ListEach lists accounts like List does, but decodes them one at a time while the response is read
+// and passes each of them to fn instead of collecting whole page in memory.
+// Listing stops at the first error returned by fn.
+func (s *AccountService) ListEach(ctx context.Context, pagination *Pagination, fn func(*models.Account) error) (*Response, error) {
+	return s.ListEachWithOptions(ctx, paginationOptions(pagination), fn)
+}
+
+// ListEachWithOptions lists accounts like ListWithOptions does, streaming them to fn like ListEach does.
+func (s *AccountService) ListEachWithOptions(ctx context.Context, opts *AccountListOptions, fn func(*models.Account) error) (*Response, error) {
+	return s.resources.ListEach(ctx, opts, fn)
+}
+
+// ListAll pages through all accounts starting at the page given in opts, following next links,
+// and passes every account to fn. Listing stops at the first error returned by fn.
+func (s *AccountService) ListAll(ctx context.Context, opts *AccountListOptions, fn func(*models.Account) error) error {
+	return s.resources.ListAll(ctx, opts, fn)
+}
+
+// ListPartial lists accounts like List does, tracking which fields of accounts were returned.
+// It is useful with sparse fieldsets, see AccountListOptions.Fields.
+func (s *AccountService) ListPartial(ctx context.Context, opts *AccountListOptions) ([]models.PartialAccount, *Response, error) {
+	return s.partial.List(ctx, opts)
+}
+
+// ListAllPartial pages through all accounts like ListAll does, tracking which fields of accounts were returned.
+func (s *AccountService) ListAllPartial(ctx context.Context, opts *AccountListOptions, fn func(*models.PartialAccount) error) error {
+	return s.partial.ListAll(ctx, opts, fn)
 }
 
 // Fetch a single account using the account ID.
 func (s *AccountService) Fetch(ctx context.Context, id string) (*models.Account, *Response, error) {
-	path := fmt.Sprintf("v1/organisation/accounts/%s", id)
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	return s.resources.Fetch(ctx, id)
+}
+
+// FetchWithOptions fetches a single account like Fetch does, e.g. including its related resources.
+func (s *AccountService) FetchWithOptions(ctx context.Context, id string, opts *AccountFetchOptions) (*models.Account, *Response, error) {
+	return s.resources.FetchWithOptions(ctx, id, opts)
+}
+
+// Update an account. ID and Version of the given account must be set and version must match current version of the account.
+func (s *AccountService) Update(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
+	return s.resources.Update(ctx, account.ID, account)
+}
+
+// UpdateClearing updates an account like Update does and removes given attributes, e.g. "bic". They are sent
+// as null, as attributes missing in an update are left unchanged.
+func (s *AccountService) UpdateClearing(ctx context.Context, account *models.Account, attributes []string) (*models.Account, *Response, error) {
+	if len(attributes) == 0 {
+		return s.Update(ctx, account)
+	}
+
+	patch, err := clearAttributes(withType(account, s.resources.Type()), attributes)
 	if err != nil {
 		return nil, nil, err
 	}
+	return s.resources.Patch(ctx, account.ID, patch)
+}
 
-	account := &models.Account{}
-	resp, err := s.client.Do(ctx, req, account)
+// clearAttributes encodes account with given attributes set to null.
+func clearAttributes(account *models.Account, attributes []string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(account)
 	if err != nil {
-		return nil, resp, err
+		return nil, err
+	}
+	var patch, attrs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch["attributes"], &attrs); err != nil {
+		return nil, err
 	}
 
-	return account, resp, err
+	for _, name := range attributes {
+		attrs[name] = json.RawMessage("null")
+	}
+	if patch["attributes"], err = json.Marshal(attrs); err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+// Close closes an account with given reason. Closed accounts are kept and can be reopened, unlike deleted ones.
+// ID, Version and Status of the given account must be set, and *InvalidTransitionError is returned if account
+// in its status cannot be closed. Accounts without status are treated as confirmed.
+func (s *AccountService) Close(ctx context.Context, account *models.Account, reason string) (*models.Account, *Response, error) {
+	return s.changeStatus(ctx, account, models.AccountClosed, func(attrs *models.AccountAttributes) {
+		attrs.StatusReason = reason
+	})
+}
+
+// Reopen confirms closed account again, clearing its status reason. Only closed accounts can be reopened.
+func (s *AccountService) Reopen(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
+	return s.changeStatus(ctx, account, models.AccountConfirmed, func(attrs *models.AccountAttributes) {
+		attrs.StatusReason = ""
+	})
+}
+
+// Switch marks account as switched to another bank under CASS, recording details of the new account
+// and clearing its status reason. Switched accounts cannot change status anymore.
+func (s *AccountService) Switch(ctx context.Context, account *models.Account, switched models.SwitchedAccount) (*models.Account, *Response, error) {
+	return s.changeStatus(ctx, account, models.AccountSwitched, func(attrs *models.AccountAttributes) {
+		attrs.StatusReason = ""
+		attrs.SwitchedAccount = &switched
+	})
+}
+
+// changeStatus validates transition of account to given status and updates the account with the status
+// and attributes set by fn. Status reason left empty is cleared. Given account is not modified.
+func (s *AccountService) changeStatus(ctx context.Context, account *models.Account, to models.AccountStatus, fn func(*models.AccountAttributes)) (*models.Account, *Response, error) {
+	from := account.Attributes.Status.Effective()
+	if !from.CanTransition(to) {
+		return nil, nil, &InvalidTransitionError{Resource: "account", ID: account.ID, From: string(from), To: string(to)}
+	}
+
+	changed := *account
+	changed.Attributes.Status = to
+	fn(&changed.Attributes)
+	var cleared []string
+	if changed.Attributes.StatusReason == "" {
+		cleared = append(cleared, "status_reason")
+	}
+	return s.UpdateClearing(ctx, &changed, cleared)
 }
 
 // Delete an account using the account ID.
 func (s *AccountService) Delete(ctx context.Context, id string) (*Response, error) {
-	path := fmt.Sprintf("v1/organisation/accounts/%s?version=0", id)
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	var accounts []models.Account
-	resp, err := s.client.Do(ctx, req, accounts)
-	return resp, err
+	return s.DeleteVersion(ctx, id, 0)
+}
+
+// DeleteVersion deletes an account using the account ID and its current version.
+func (s *AccountService) DeleteVersion(ctx context.Context, id string, version int) (*Response, error) {
+	return s.resources.Delete(ctx, id, version)
 }
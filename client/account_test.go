@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -509,3 +510,389 @@ func TestAccountService_DeleteRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountService_ListEach(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenResponse      string
+		givenStatusCode    int
+		expectedAccountIDs []string
+		expectedError      string
+	}{
+		{
+			name: "it should pass every listed account to the callback",
+			givenResponse: `{
+				"data": [
+					{"id": "bdf9e1a8-481e-483f-b54c-7103cffceb21", "type": "accounts", "attributes": {"country": "GB"}},
+					{"id": "63c0a226-5b6c-4ef9-a0bb-436dd39d45bb", "type": "accounts", "attributes": {"country": "GB"}}
+				],
+				"links": {
+					"self": "/v1/organisation/accounts"
+				}
+			}`,
+			givenStatusCode:    http.StatusOK,
+			expectedAccountIDs: []string{"bdf9e1a8-481e-483f-b54c-7103cffceb21", "63c0a226-5b6c-4ef9-a0bb-436dd39d45bb"},
+		},
+		{
+			name:            "it should return custom api error on internal server error status",
+			givenResponse:   `{"error_message": "custom error message"}`,
+			givenStatusCode: http.StatusInternalServerError,
+			expectedError:   "code: 500, message: custom error message",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "2", r.URL.Query().Get("page[number]"))
+				w.WriteHeader(test.givenStatusCode)
+				fmt.Fprintf(w, test.givenResponse)
+			}).Methods(http.MethodGet)
+
+			var ids []string
+			_, err := client.Account.ListEach(context.TODO(), &Pagination{Page: 2}, func(acc *models.Account) error {
+				ids = append(ids, acc.ID)
+				return nil
+			})
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			if assert.Nil(t, err) {
+				assert.Equal(t, test.expectedAccountIDs, ids)
+			}
+		})
+	}
+}
+
+func TestAccountService_ListRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenOptions  *AccountListOptions
+		expectedQuery string
+	}{
+		{
+			name:          "it should not add query parameters without options",
+			givenOptions:  nil,
+			expectedQuery: "",
+		},
+		{
+			name: "it should add pagination and filter query parameters",
+			givenOptions: &AccountListOptions{
+				Pagination: Pagination{Page: 1, PerPage: 10},
+				Filter:     &AccountFilter{Country: "GB", BankID: "400302"},
+			},
+			expectedQuery: "filter[bank_id]=400302&filter[country]=GB&page[number]=1&page[size]=10",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+			var isCalled bool
+
+			router.Path("/v1/organisation/accounts").
+				Methods(http.MethodGet).
+				HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					isCalled = true
+					query, err := url.QueryUnescape(r.URL.RawQuery)
+					if assert.Nil(t, err) {
+						assert.Equal(t, test.expectedQuery, query)
+					}
+				})
+
+			_, _, _ = client.Account.ListWithOptions(context.TODO(), test.givenOptions)
+			assert.True(t, isCalled)
+		})
+	}
+}
+
+func TestAccountService_Update(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenResponse   string
+		givenStatusCode int
+		expectedVersion int
+		expectedError   string
+	}{
+		{
+			name:            "it should return updated account on valid response",
+			givenResponse:   `{"data": {"id": "account-id", "type": "accounts", "version": 2}}`,
+			givenStatusCode: http.StatusOK,
+			expectedVersion: 2,
+		},
+		{
+			name:            "it should return custom api error on version conflict",
+			givenResponse:   `{"error_message": "invalid version"}`,
+			givenStatusCode: http.StatusConflict,
+			expectedError:   "code: 409, message: invalid version",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+				data, err := ioutil.ReadAll(r.Body)
+				if assert.Nil(t, err) {
+					assert.Equal(t, "account-id", mux.Vars(r)["id"])
+					assert.Contains(t, string(data), `"version":1`)
+				}
+				w.WriteHeader(test.givenStatusCode)
+				fmt.Fprintf(w, test.givenResponse)
+			}).Methods(http.MethodPatch)
+
+			acc, _, err := client.Account.Update(context.TODO(), &models.Account{ID: "account-id", Version: 1})
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			if assert.Nil(t, err) {
+				assert.Equal(t, test.expectedVersion, acc.Version)
+			}
+		})
+	}
+}
+
+func TestAccountService_UpdateClearing(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenAttributes []string
+		expectedBody    string
+	}{
+		{
+			name:            "it should send cleared attributes as null",
+			givenAttributes: []string{"bic", "iban"},
+			expectedBody:    `{"data":{"attributes":{"bic":null,"country":"GB","iban":null},"id":"account-id","organisation_id":"","type":"accounts","version":1}}`,
+		},
+		{
+			name:         "it should update account when no attributes are cleared",
+			expectedBody: `{"data":{"attributes":{"country":"GB"},"id":"account-id","organisation_id":"","type":"accounts","version":1}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+				data, err := ioutil.ReadAll(r.Body)
+				if assert.Nil(t, err) {
+					assert.JSONEq(t, test.expectedBody, string(data))
+				}
+				fmt.Fprint(w, `{"data": {"id": "account-id", "type": "accounts", "version": 2}}`)
+			}).Methods(http.MethodPatch)
+
+			acc := &models.Account{ID: "account-id", Version: 1, Attributes: models.AccountAttributes{Country: "GB"}}
+			updated, _, err := client.Account.UpdateClearing(context.TODO(), acc, test.givenAttributes)
+			require.Nil(t, err)
+			assert.Equal(t, 2, updated.Version)
+		})
+	}
+}
+
+func TestAccountService_DeleteVersion(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	var isCalled bool
+
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		isCalled = true
+		assert.Equal(t, "3", r.URL.Query().Get("version"))
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodDelete)
+
+	_, err := client.Account.DeleteVersion(context.TODO(), "account-id", 3)
+	assert.Nil(t, err)
+	assert.True(t, isCalled)
+}
+
+func TestAccountService_ListAll(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page[number]") {
+		case "1":
+			fmt.Fprint(w, `{"data":[{"id":"a"},{"id":"b"}],"links":{"next":"/v1/organisation/accounts?page[number]=2&page[size]=2"}}`)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"id":"c"}],"links":{"prev":"/v1/organisation/accounts?page[number]=1&page[size]=2"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error_message":"page not found"}`)
+		}
+	}).Methods(http.MethodGet)
+
+	var ids []string
+	err := client.Account.ListAll(context.TODO(), &AccountListOptions{Pagination: Pagination{Page: 1, PerPage: 2}}, func(acc *models.Account) error {
+		ids = append(ids, acc.ID)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+
+	err = client.Account.ListAll(context.TODO(), &AccountListOptions{Pagination: Pagination{Page: 3}}, func(acc *models.Account) error {
+		return nil
+	})
+	assert.EqualError(t, err, "code: 404, message: page not found")
+}
+
+func TestAccountService_ListAllFilter(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	var pages []string
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GB", r.URL.Query().Get("filter[country]"))
+		assert.Equal(t, "2", r.URL.Query().Get("page[size]"))
+		pages = append(pages, r.URL.Query().Get("page[number]"))
+		switch r.URL.Query().Get("page[number]") {
+		case "1":
+			fmt.Fprint(w, `{"data":[{"id":"a"},{"id":"b"}],"links":{"next":"/v1/organisation/accounts?page[number]=2&page[size]=2"}}`)
+		default:
+			fmt.Fprint(w, `{"data":[{"id":"c"}]}`)
+		}
+	}).Methods(http.MethodGet)
+
+	opts := &AccountListOptions{Pagination: Pagination{Page: 1, PerPage: 2}, Filter: &AccountFilter{Country: "GB"}}
+	err := client.Account.ListAll(context.TODO(), opts, func(acc *models.Account) error {
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages, "it should follow next link and keep the filter")
+}
+
+func TestAccountService_ListPartial(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bank_id,account_number", r.URL.Query().Get("fields[accounts]"))
+		assert.Equal(t, "-bank_id,account_number", r.URL.Query().Get("sort"))
+		fmt.Fprint(w, `{"data":[{"id":"a","attributes":{"bank_id":"400302","account_number":"10000004"}},{"id":"b","attributes":{"bank_id":"400300"}}]}`)
+	}).Methods(http.MethodGet)
+
+	accounts, _, err := client.Account.ListPartial(context.TODO(), &AccountListOptions{
+		Fields: []string{"bank_id", "account_number"},
+		Sort:   []string{"-bank_id", "account_number"},
+	})
+	require.Nil(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "10000004", accounts[0].Attributes.AccountNumber)
+	assert.Equal(t, models.FieldSet{"id": true, "bank_id": true, "account_number": true}, accounts[0].Fields)
+	assert.True(t, accounts[1].Fields.Has("bank_id"))
+	assert.False(t, accounts[1].Fields.Has("account_number"), "it should not report missing attribute as present")
+	assert.False(t, accounts[1].Fields.Has("country"))
+}
+
+func TestAccountService_ChangeStatus(t *testing.T) {
+	switched := models.SwitchedAccount{AccountNumber: "10000004", BankID: "400300", BankIDCode: "GBDSC", SwitchedDate: "2020-01-31"}
+	tests := []struct {
+		name          string
+		givenStatus   models.AccountStatus
+		givenChange   func(*AccountService, *models.Account) (*models.Account, *Response, error)
+		expectedBody  string
+		expectedError string
+	}{
+		{
+			name:        "it should close confirmed account with reason",
+			givenStatus: models.AccountConfirmed,
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Close(context.TODO(), acc, "customer request")
+			},
+			expectedBody: `{"data":{"id":"account-id","organisation_id":"","type":"accounts","version":3,"attributes":{"country":"GB","status":"closed","status_reason":"customer request"}}}`,
+		},
+		{
+			name: "it should close account without status",
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Close(context.TODO(), acc, "customer request")
+			},
+			expectedBody: `{"data":{"id":"account-id","organisation_id":"","type":"accounts","version":3,"attributes":{"country":"GB","status":"closed","status_reason":"customer request"}}}`,
+		},
+		{
+			name:        "it should not close pending account",
+			givenStatus: models.AccountPending,
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Close(context.TODO(), acc, "customer request")
+			},
+			expectedError: `account account-id cannot change status from "pending" to "closed"`,
+		},
+		{
+			name:        "it should reopen closed account",
+			givenStatus: models.AccountClosed,
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Reopen(context.TODO(), acc)
+			},
+			expectedBody: `{"data":{"id":"account-id","organisation_id":"","type":"accounts","version":3,"attributes":{"country":"GB","status":"confirmed","status_reason":null}}}`,
+		},
+		{
+			name:        "it should not reopen pending account",
+			givenStatus: models.AccountPending,
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Reopen(context.TODO(), acc)
+			},
+			expectedError: `account account-id cannot change status from "pending" to "confirmed"`,
+		},
+		{
+			name:        "it should not reopen switched account",
+			givenStatus: models.AccountSwitched,
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Reopen(context.TODO(), acc)
+			},
+			expectedError: `account account-id cannot change status from "switched" to "confirmed"`,
+		},
+		{
+			name:        "it should switch confirmed account with details of the new account",
+			givenStatus: models.AccountConfirmed,
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Switch(context.TODO(), acc, switched)
+			},
+			expectedBody: `{"data":{"id":"account-id","organisation_id":"","type":"accounts","version":3,"attributes":{"country":"GB","status":"switched","status_reason":null,"switched_account_details":{"account_number":"10000004","bank_id":"400300","bank_id_code":"GBDSC","switched_date":"2020-01-31"}}}}`,
+		},
+		{
+			name:        "it should not switch closed account",
+			givenStatus: models.AccountClosed,
+			givenChange: func(s *AccountService, acc *models.Account) (*models.Account, *Response, error) {
+				return s.Switch(context.TODO(), acc, switched)
+			},
+			expectedError: `account account-id cannot change status from "closed" to "switched"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+			var isCalled bool
+
+			router.HandleFunc("/v1/organisation/accounts/account-id", func(w http.ResponseWriter, r *http.Request) {
+				isCalled = true
+				data, err := ioutil.ReadAll(r.Body)
+				if assert.Nil(t, err) {
+					assert.JSONEq(t, test.expectedBody, string(data))
+				}
+				fmt.Fprint(w, `{"data": {"id": "account-id", "type": "accounts", "version": 4}}`)
+			}).Methods(http.MethodPatch)
+
+			account := &models.Account{ID: "account-id", Version: 3, Attributes: models.AccountAttributes{
+				Country:      "GB",
+				Status:       test.givenStatus,
+				StatusReason: "previous reason",
+			}}
+			acc, _, err := test.givenChange(client.Account, account)
+			assert.Equal(t, test.givenStatus, account.Attributes.Status, "it should not modify given account")
+			if test.expectedError != "" {
+				assert.False(t, isCalled)
+				assert.EqualError(t, err, test.expectedError)
+				_, ok := err.(*InvalidTransitionError)
+				assert.True(t, ok)
+				return
+			}
+			require.Nil(t, err)
+			assert.True(t, isCalled)
+			assert.Equal(t, 4, acc.Version)
+		})
+	}
+}
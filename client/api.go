@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// AccountAPI covers all account operations. It is implemented by AccountService, and by the mock and
+// the in-memory fake in clienttest package, so consumers can unit test without running API.
+type AccountAPI interface {
+	Create(ctx context.Context, account *models.Account) (*models.Account, *Response, error)
+	Fetch(ctx context.Context, id string) (*models.Account, *Response, error)
+	FetchWithOptions(ctx context.Context, id string, opts *AccountFetchOptions) (*models.Account, *Response, error)
+	List(ctx context.Context, pagination *Pagination) ([]models.Account, *Response, error)
+	ListWithOptions(ctx context.Context, opts *AccountListOptions) ([]models.Account, *Response, error)
+	ListEach(ctx context.Context, pagination *Pagination, fn func(*models.Account) error) (*Response, error)
+	ListEachWithOptions(ctx context.Context, opts *AccountListOptions, fn func(*models.Account) error) (*Response, error)
+	ListAll(ctx context.Context, opts *AccountListOptions, fn func(*models.Account) error) error
+	ListPartial(ctx context.Context, opts *AccountListOptions) ([]models.PartialAccount, *Response, error)
+	ListAllPartial(ctx context.Context, opts *AccountListOptions, fn func(*models.PartialAccount) error) error
+	Update(ctx context.Context, account *models.Account) (*models.Account, *Response, error)
+	Delete(ctx context.Context, id string) (*Response, error)
+	DeleteVersion(ctx context.Context, id string, version int) (*Response, error)
+	Close(ctx context.Context, account *models.Account, reason string) (*models.Account, *Response, error)
+	Reopen(ctx context.Context, account *models.Account) (*models.Account, *Response, error)
+	Switch(ctx context.Context, account *models.Account, switched models.SwitchedAccount) (*models.Account, *Response, error)
+	Watch(ctx context.Context, interval time.Duration, filter *AccountFilter) <-chan AccountEvent
+}
+
+var _ AccountAPI = (*AccountService)(nil)
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+)
+
+// headerCache is set on responses served from Cache, so they can be told apart from fresh responses.
+const headerCache = "X-From-Cache"
+
+// Cache is an in-memory HTTP cache for GET requests. Responses are stored keyed by URL and
+// revalidated using If-None-Match and If-Modified-Since headers; 304 responses are served from cache.
+// Within TTL, cached responses are served without contacting the API at all.
+// Successful non GET requests invalidate cached resource and its collection.
+// Responses which expired and cannot be revalidated are dropped, and the oldest responses are evicted
+// when the cache holds too many of them.
+type Cache struct {
+	transport  http.RoundTripper
+	ttl        time.Duration
+	now        func() time.Time
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// defaultMaxCacheEntries limits number of responses held by Cache.
+const defaultMaxCacheEntries = 1000
+
+// cacheEntry is never modified once stored, so it can be read without holding Cache lock.
+type cacheEntry struct {
+	path         string
+	header       http.Header
+	body         []byte
+	storedAt     time.Time
+	etag         string
+	lastModified string
+}
+
+// NewCache creates a new Cache which sends requests using given transport.
+// If transport is nil, http.DefaultTransport is used. Zero ttl means every request is revalidated.
+func NewCache(transport http.RoundTripper, ttl time.Duration) *Cache {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Cache{
+		transport:  transport,
+		ttl:        ttl,
+		now:        time.Now,
+		maxEntries: defaultMaxCacheEntries,
+		entries:    make(map[string]*cacheEntry),
+	}
+}
+
+// EnableCache turns on caching of GET responses for the client and returns the cache,
+// so it can be invalidated explicitly. Given http client is copied, not modified.
+func (c *Client) EnableCache(ttl time.Duration) *Cache {
+	httpClient := *c.httpClient
+	cache := NewCache(httpClient.Transport, ttl)
+	httpClient.Transport = cache
+	c.httpClient = &httpClient
+	return cache
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := c.transport.RoundTrip(req)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+			c.invalidatePath(req.URL.Path)
+		}
+		return resp, err
+	}
+
+	key := req.URL.String()
+	c.mu.Lock()
+	entry := c.entries[key]
+	fresh := entry != nil && c.fresh(entry)
+	c.mu.Unlock()
+
+	if fresh {
+		return entry.response(req), nil
+	}
+
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		revalidated := *entry
+		revalidated.storedAt = c.now()
+		c.store(key, &revalidated)
+		return revalidated.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	etag := resp.Header.Get(headerETag)
+	lastModified := resp.Header.Get(headerLastModified)
+	if etag == "" && lastModified == "" && c.ttl <= 0 {
+		// nothing to revalidate with and nothing to keep fresh
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	c.store(key, &cacheEntry{
+		path:         req.URL.Path,
+		header:       resp.Header.Clone(),
+		body:         body,
+		storedAt:     c.now(),
+		etag:         etag,
+		lastModified: lastModified,
+	})
+	return resp, nil
+}
+
+// fresh reports whether entry can be served without contacting the API. c.mu must be held.
+func (c *Cache) fresh(e *cacheEntry) bool {
+	return c.ttl > 0 && c.now().Sub(e.storedAt) < c.ttl
+}
+
+// store saves entry, evicting entries which expired and cannot be revalidated, and the oldest
+// entries if cache is full.
+func (c *Cache) store(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+	for k, e := range c.entries {
+		if e.etag == "" && e.lastModified == "" && !c.fresh(e) {
+			delete(c.entries, k)
+		}
+	}
+	for c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		oldest := ""
+		for k, e := range c.entries {
+			if oldest == "" || e.storedAt.Before(c.entries[oldest].storedAt) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+}
+
+// Invalidate removes cached responses of given resource URL and of the collection it belongs to.
+// Relative URLs are accepted, only the path is taken into account.
+func (c *Cache) Invalidate(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	c.invalidatePath(u.Path)
+	return nil
+}
+
+// Purge removes all cached responses.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	c.entries = make(map[string]*cacheEntry)
+	c.mu.Unlock()
+}
+
+func (c *Cache) invalidatePath(p string) {
+	p = path.Clean("/" + p)
+	parent := path.Dir(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		entryPath := path.Clean("/" + entry.path)
+		if entryPath == p || entryPath == parent {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// response builds http.Response from cached entry for given request.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	header := e.header.Clone()
+	header.Set(headerCache, "1")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
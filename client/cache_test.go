@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cachedAccountResponse = `{"data":{"id":"account-id","type":"accounts","version":0}}`
+
+func TestCache_Revalidate(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenHeaders      map[string]string
+		expectedCondition string
+		expectedValue     string
+	}{
+		{
+			name:              "it should send If-None-Match with stored etag",
+			givenHeaders:      map[string]string{"ETag": `"v1"`},
+			expectedCondition: "If-None-Match",
+			expectedValue:     `"v1"`,
+		},
+		{
+			name:              "it should send If-Modified-Since with stored last modified time",
+			givenHeaders:      map[string]string{"Last-Modified": "Wed, 02 Oct 2019 13:34:32 GMT"},
+			expectedCondition: "If-Modified-Since",
+			expectedValue:     "Wed, 02 Oct 2019 13:34:32 GMT",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+			client.EnableCache(0)
+
+			calls := 0
+			router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				for k, v := range test.givenHeaders {
+					w.Header().Set(k, v)
+				}
+				if calls > 1 {
+					assert.Equal(t, test.expectedValue, r.Header.Get(test.expectedCondition))
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				fmt.Fprint(w, cachedAccountResponse)
+			}).Methods(http.MethodGet)
+
+			acc, resp, err := client.Account.Fetch(context.TODO(), "account-id")
+			require.Nil(t, err)
+			assert.False(t, resp.FromCache)
+			assert.Equal(t, "account-id", acc.ID)
+
+			acc, resp, err = client.Account.Fetch(context.TODO(), "account-id")
+			require.Nil(t, err)
+			assert.True(t, resp.FromCache)
+			assert.Equal(t, "account-id", acc.ID)
+			assert.Equal(t, 2, calls)
+		})
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	cache := client.EnableCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, cachedAccountResponse)
+	}).Methods(http.MethodGet)
+
+	for i := 0; i < 3; i++ {
+		_, _, err := client.Account.Fetch(context.TODO(), "account-id")
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 1, calls, "it should serve fresh responses from cache")
+
+	now = now.Add(2 * time.Minute)
+	_, _, err := client.Account.Fetch(context.TODO(), "account-id")
+	require.Nil(t, err)
+	assert.Equal(t, 2, calls, "it should send request when cached response expired")
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	cache := client.EnableCache(time.Minute)
+
+	calls := 0
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, cachedAccountResponse)
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodDelete)
+
+	_, _, _ = client.Account.Fetch(context.TODO(), "account-id")
+	_, err := client.Account.Delete(context.TODO(), "account-id")
+	require.Nil(t, err)
+	_, _, _ = client.Account.Fetch(context.TODO(), "account-id")
+	assert.Equal(t, 2, calls, "it should invalidate cached resource after delete")
+
+	require.Nil(t, cache.Invalidate("/v1/organisation/accounts/account-id"))
+	_, _, _ = client.Account.Fetch(context.TODO(), "account-id")
+	assert.Equal(t, 3, calls, "it should invalidate cached resource explicitly")
+
+	cache.Purge()
+	_, _, _ = client.Account.Fetch(context.TODO(), "account-id")
+	assert.Equal(t, 4, calls, "it should purge all cached resources")
+}
+
+func TestClient_EnableCacheDoesNotModifyGivenClient(t *testing.T) {
+	httpClient := &http.Client{}
+	client := NewClient(httpClient, nil)
+	client.EnableCache(time.Minute)
+
+	assert.Nil(t, httpClient.Transport)
+	assert.IsType(t, &Cache{}, client.httpClient.Transport)
+}
+
+func TestCache_Evict(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	cache := client.EnableCache(time.Minute)
+	cache.maxEntries = 2
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, cachedAccountResponse)
+	}).Methods(http.MethodGet)
+
+	for _, id := range []string{"a", "b", "c"} {
+		now = now.Add(time.Second)
+		_, _, err := client.Account.Fetch(context.TODO(), id)
+		require.Nil(t, err)
+	}
+	assert.Len(t, cache.entries, 2, "it should evict the oldest entry when cache is full")
+	assert.NotContains(t, cache.entries, server.URL+"/v1/organisation/accounts/a")
+
+	now = now.Add(2 * time.Minute)
+	_, _, err := client.Account.Fetch(context.TODO(), "d")
+	require.Nil(t, err)
+	assert.Len(t, cache.entries, 1, "it should drop expired entries which cannot be revalidated")
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	client.EnableCache(0)
+
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, cachedAccountResponse)
+	}).Methods(http.MethodGet)
+
+	done := make(chan error)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, _, err := client.Account.Fetch(context.TODO(), "account-id")
+			done <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, <-done)
+	}
+}
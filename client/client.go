@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"time"
 
 	"github.com/google/go-querystring/query"
+	"github.com/rhymond/interview-accountapi/models"
 )
 
 var contentType = "application/vnd.api+json"
@@ -19,7 +21,12 @@ type Client struct {
 	BaseURL    *url.URL
 	httpClient *http.Client
 
-	Account *AccountService
+	Account          *AccountService
+	Mandate          *MandateService
+	NameVerification *NameVerificationService
+	Organisation     *OrganisationService
+	Payment          *PaymentService
+	Subscription     *SubscriptionService
 }
 
 // Pagination is a structure required to build query parameters for pagination.
@@ -37,14 +44,49 @@ func NewClient(httpClient *http.Client, baseURL *url.URL) *Client {
 		BaseURL:    baseURL,
 		httpClient: httpClient,
 	}
-	c.Account = &AccountService{client: c}
+	c.Account = &AccountService{
+		resources: NewResourceService[models.Account](c, "accounts", "v1/organisation/accounts"),
+		partial:   NewResourceService[models.PartialAccount](c, "accounts", "v1/organisation/accounts"),
+	}
+	c.Mandate = &MandateService{resources: NewResourceService[models.Mandate](c, "mandates", "v1/transaction/mandates")}
+	c.Organisation = &OrganisationService{resources: NewResourceService[models.Organisation](c, "organisations", "v1/organisation/units")}
+	c.Payment = &PaymentService{client: c, resources: NewResourceService[models.Payment](c, "payments", "v1/transaction/payments")}
+	c.Subscription = &SubscriptionService{resources: NewResourceService[models.Subscription](c, "subscriptions", "v1/notification/subscriptions")}
+	c.NameVerification = &NameVerificationService{client: c}
 	return c
 }
 
 // addOptions adds query parameters to given path.
 func addOptions(s string, opt interface{}) (string, error) {
 	v := reflect.ValueOf(opt)
-	if v.Kind() == reflect.Ptr && v.IsNil() {
+	if opt == nil || v.Kind() == reflect.Ptr && v.IsNil() {
+		return s, nil
+	}
+
+	origURL, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+
+	origValues := origURL.Query()
+	newValues, err := query.Values(opt)
+	if err != nil {
+		return s, err
+	}
+
+	for k, v := range newValues {
+		origValues[k] = v
+	}
+	origURL.RawQuery = origValues.Encode()
+	return origURL.String(), nil
+}
+
+// addMissingOptions adds parameters of opt to the URL s like addOptions does, but only those which s does
+// not already have. Page number is never added. It is used to keep filters when following next links,
+// which servers may not repeat.
+func addMissingOptions(s string, opt interface{}) (string, error) {
+	v := reflect.ValueOf(opt)
+	if opt == nil || v.Kind() == reflect.Ptr && v.IsNil() {
 		return s, nil
 	}
 
@@ -59,8 +101,16 @@ func addOptions(s string, opt interface{}) (string, error) {
 		return s, err
 	}
 
+	changed := false
 	for k, v := range newValues {
+		if _, ok := origValues[k]; ok || k == "page[number]" {
+			continue
+		}
 		origValues[k] = v
+		changed = true
+	}
+	if !changed {
+		return s, nil
 	}
 	origURL.RawQuery = origValues.Encode()
 	return origURL.String(), nil
@@ -104,6 +154,7 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 // pointed to by v, or returned as an error if an API error has occurred.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
 	req = req.WithContext(ctx)
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -116,6 +167,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	}()
 
 	r := NewResponse(resp)
+	r.Duration = time.Since(start)
 	err = checkResponse(resp)
 	if err != nil {
 		return r, err
@@ -147,7 +199,7 @@ func checkResponse(r *http.Response) error {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r, StatusCode: r.StatusCode}
+	errorResponse := &ErrorResponse{Response: r, StatusCode: r.StatusCode, RequestID: requestID(r.Header)}
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && len(data) > 0 {
 		err := json.Unmarshal(data, errorResponse)
@@ -131,3 +131,34 @@ func TestNewRequest(t *testing.T) {
 	require.Nil(t, err)
 	assert.Equal(t, "{\"data\":{\"A\":\"B\"}}\n", string(reqBody))
 }
+
+func TestDo_ResponseMetadata(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":{},"meta":{"count":1}}`))
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-2")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_message":"not found"}`))
+	}).Methods(http.MethodGet)
+
+	req, _ := client.NewRequest(context.TODO(), http.MethodGet, "/", nil)
+	resp, err := client.Do(context.TODO(), req, nil)
+	require.Nil(t, err)
+	assert.Equal(t, "req-1", resp.RequestID)
+	assert.Equal(t, `"v1"`, resp.ETag)
+	assert.Equal(t, `{"count":1}`, string(resp.Meta))
+	assert.True(t, resp.Duration > 0)
+
+	req, _ = client.NewRequest(context.TODO(), http.MethodGet, "/missing", nil)
+	_, err = client.Do(context.TODO(), req, nil)
+	if assert.IsType(t, &ErrorResponse{}, err) {
+		assert.Equal(t, "req-2", err.(*ErrorResponse).RequestID)
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// DecodeIncluded finds resource with given identifier among included resources of the response and decodes
+// it into the value pointed to by v. It reports whether the resource was included.
+func (r *Response) DecodeIncluded(id models.ResourceIdentifier, v interface{}) (bool, error) {
+	for _, raw := range r.Included {
+		var ident models.ResourceIdentifier
+		if err := json.Unmarshal(raw, &ident); err != nil {
+			return false, err
+		}
+		if ident == id {
+			return true, json.Unmarshal(raw, v)
+		}
+	}
+	return false, nil
+}
+
+// Included returns included resources of given type, e.g. "accounts", decoded as T.
+func Included[T any](r *Response, resourceType string) ([]T, error) {
+	var resources []T
+	for _, raw := range r.Included {
+		var ident models.ResourceIdentifier
+		if err := json.Unmarshal(raw, &ident); err != nil {
+			return nil, err
+		}
+		if ident.Type != resourceType {
+			continue
+		}
+
+		var resource T
+		if err := json.Unmarshal(raw, &resource); err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// Resolve returns resource related by rel decoded as T, or nil if it is not included in the response.
+//
+//	acc, resp, err := client.Account.FetchWithOptions(ctx, id, &AccountFetchOptions{Include: []string{IncludeMasterAccount}})
+//	master, err := Resolve[models.Account](resp, acc.Relationships.MasterAccount)
+func Resolve[T any](r *Response, rel *models.Relationship) (*T, error) {
+	if rel == nil || rel.Data == nil {
+		return nil, nil
+	}
+
+	resource := new(T)
+	ok, err := r.DecodeIncluded(*rel.Data, resource)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return resource, nil
+}
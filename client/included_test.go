@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const includedResponse = `{
+	"data": {"id":"account-id","type":"accounts","relationships":{
+		"master_account":{"data":{"id":"master-id","type":"accounts"}},
+		"organisation":{"data":{"id":"organisation-id","type":"organisations"}}
+	}},
+	"included": [
+		{"id":"master-id","type":"accounts","attributes":{"country":"GB","bank_id":"400300"}},
+		{"id":"organisation-id","type":"organisations","attributes":{"name":"Retail"}}
+	]
+}`
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenRelation   *models.Relationship
+		expectedCountry string
+		expectedNil     bool
+	}{
+		{
+			name:            "it should decode included resource",
+			givenRelation:   models.NewRelationship("accounts", "master-id"),
+			expectedCountry: "GB",
+		},
+		{
+			name:          "it should return nil when resource is not included",
+			givenRelation: models.NewRelationship("accounts", "other-id"),
+			expectedNil:   true,
+		},
+		{
+			name:          "it should match type of resource",
+			givenRelation: models.NewRelationship("organisations", "master-id"),
+			expectedNil:   true,
+		},
+		{
+			name:        "it should return nil on missing relationship",
+			expectedNil: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &Response{}
+			require.Nil(t, json.Unmarshal([]byte(includedResponse), resp))
+
+			acc, err := Resolve[models.Account](resp, test.givenRelation)
+			require.Nil(t, err)
+			if test.expectedNil {
+				assert.Nil(t, acc)
+				return
+			}
+			require.NotNil(t, acc)
+			assert.Equal(t, test.expectedCountry, acc.Attributes.Country)
+		})
+	}
+}
+
+func TestIncluded(t *testing.T) {
+	resp := &Response{}
+	require.Nil(t, json.Unmarshal([]byte(includedResponse), resp))
+
+	orgs, err := Included[models.Organisation](resp, "organisations")
+	require.Nil(t, err)
+	require.Len(t, orgs, 1)
+	assert.Equal(t, "Retail", orgs[0].Attributes.Name)
+
+	resp.Included = append(resp.Included, json.RawMessage(`not-a-json`))
+	_, err = Included[models.Organisation](resp, "organisations")
+	assert.Error(t, err)
+}
+
+func TestAccountService_FetchWithOptions(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "master_account,organisation", r.URL.Query().Get("include"))
+		fmt.Fprint(w, includedResponse)
+	}).Methods(http.MethodGet)
+
+	acc, resp, err := client.Account.FetchWithOptions(context.TODO(), "account-id", &AccountFetchOptions{
+		Include: []string{IncludeMasterAccount, IncludeOrganisation},
+	})
+	require.Nil(t, err)
+	require.NotNil(t, acc.Relationships)
+	assert.Equal(t, "master-id", acc.Relationships.MasterAccount.ID())
+
+	master, err := Resolve[models.Account](resp, acc.Relationships.MasterAccount)
+	require.Nil(t, err)
+	assert.Equal(t, "400300", master.Attributes.BankID)
+
+	org, err := Resolve[models.Organisation](resp, acc.Relationships.Organisation)
+	require.Nil(t, err)
+	assert.Equal(t, "Retail", org.Attributes.Name)
+}
+
+func TestAccountService_ListAllIncluded(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "organisation", r.URL.Query().Get("include"))
+		fmt.Fprint(w, `{"included":[{"id":"organisation-id","type":"organisations"}],"data":[{"id":"a"}]}`)
+	}).Methods(http.MethodGet)
+
+	resp, err := client.Account.ListEachWithOptions(context.TODO(), &AccountListOptions{Include: []string{IncludeOrganisation}}, func(*models.Account) error {
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Len(t, resp.Included, 1, "it should keep included resources of streamed response")
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// MandateService holds API functionality for direct debit mandates API.
+type MandateService struct {
+	resources *ResourceService[models.Mandate]
+}
+
+// MandateFilter is a structure required to build query parameters for filtering mandates list.
+type MandateFilter struct {
+	Scheme          string               `url:"scheme,omitempty"`
+	Reference       string               `url:"reference,omitempty"`
+	Status          models.MandateStatus `url:"status,omitempty"`
+	DebtorAccountID string               `url:"debtor_account_id,omitempty"`
+}
+
+// MandateListOptions specifies optional parameters for listing mandates.
+type MandateListOptions struct {
+	Pagination
+	Filter *MandateFilter `url:"filter,omitempty"`
+}
+
+// Create creates a mandate. Scheme, reference and both account relationships must be specified.
+func (s *MandateService) Create(ctx context.Context, mandate *models.Mandate) (*models.Mandate, *Response, error) {
+	return s.resources.Create(ctx, mandate)
+}
+
+// Fetch a single mandate using the mandate ID.
+func (s *MandateService) Fetch(ctx context.Context, id string) (*models.Mandate, *Response, error) {
+	return s.resources.Fetch(ctx, id)
+}
+
+// List mandates with the ability to filter and page.
+func (s *MandateService) List(ctx context.Context, opts *MandateListOptions) ([]models.Mandate, *Response, error) {
+	return s.resources.List(ctx, opts)
+}
+
+// Cancel cancels the mandate with given reason. ID, Version and Status of the given mandate must be set,
+// and *InvalidTransitionError is returned if mandate in its status cannot be cancelled.
+func (s *MandateService) Cancel(ctx context.Context, mandate *models.Mandate, reason string) (*models.Mandate, *Response, error) {
+	status := mandate.Attributes.Status
+	if !status.CanTransition(models.MandateCancelled) {
+		return nil, nil, &InvalidTransitionError{Resource: "mandate", ID: mandate.ID, From: string(status), To: string(models.MandateCancelled)}
+	}
+
+	return s.resources.Update(ctx, mandate.ID, &models.Mandate{
+		ID:         mandate.ID,
+		Type:       mandate.Type,
+		Version:    mandate.Version,
+		Attributes: models.MandateAttributes{Status: models.MandateCancelled, StatusReason: reason},
+	})
+}
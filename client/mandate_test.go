@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMandateService_Create(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/transaction/mandates", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err)
+		data := string(body)
+		assert.Contains(t, data, `"debtor_account":{"data":{"id":"debtor-id","type":"accounts"}}`)
+		assert.Contains(t, data, `"creditor_account":{"data":{"id":"creditor-id","type":"accounts"}}`)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"mandate-id","type":"mandates","attributes":{"scheme":"bacs","reference":"REF-1","status":"pending"},
+			"relationships":{"debtor_account":{"data":{"id":"debtor-id","type":"accounts"}}}}}`)
+	}).Methods(http.MethodPost)
+
+	mandate := &models.Mandate{
+		ID:         "mandate-id",
+		Type:       "mandates",
+		Attributes: models.MandateAttributes{Scheme: models.SchemeBacs, Reference: "REF-1"},
+		Relationships: models.MandateRelationships{
+			DebtorAccount:   models.NewRelationship("accounts", "debtor-id"),
+			CreditorAccount: models.NewRelationship("accounts", "creditor-id"),
+		},
+	}
+	m, _, err := client.Mandate.Create(context.TODO(), mandate)
+	require.Nil(t, err)
+	assert.Equal(t, models.MandatePending, m.Attributes.Status)
+	assert.Equal(t, "debtor-id", m.Relationships.DebtorAccount.ID())
+	assert.Equal(t, "", m.Relationships.CreditorAccount.ID())
+}
+
+func TestMandateService_List(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/transaction/mandates", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "active", r.URL.Query().Get("filter[status]"))
+		assert.Equal(t, "debtor-id", r.URL.Query().Get("filter[debtor_account_id]"))
+		fmt.Fprint(w, `{"data":[{"id":"a","attributes":{"status":"active"}}]}`)
+	}).Methods(http.MethodGet)
+
+	mandates, _, err := client.Mandate.List(context.TODO(), &MandateListOptions{
+		Filter: &MandateFilter{Status: models.MandateActive, DebtorAccountID: "debtor-id"},
+	})
+	require.Nil(t, err)
+	require.Len(t, mandates, 1)
+	assert.Equal(t, "a", mandates[0].ID)
+}
+
+func TestMandateService_Cancel(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenStatus   models.MandateStatus
+		expectedCall  bool
+		expectedError string
+	}{
+		{
+			name:         "it should cancel active mandate",
+			givenStatus:  models.MandateActive,
+			expectedCall: true,
+		},
+		{
+			name:          "it should not cancel failed mandate",
+			givenStatus:   models.MandateFailed,
+			expectedError: `mandate mandate-id cannot change status from "failed" to "cancelled"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+			var isCalled bool
+
+			router.HandleFunc("/v1/transaction/mandates/mandate-id", func(w http.ResponseWriter, r *http.Request) {
+				isCalled = true
+				var req struct {
+					Data models.Mandate `json:"data"`
+				}
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, 3, req.Data.Version)
+				assert.Equal(t, models.MandateCancelled, req.Data.Attributes.Status)
+				assert.Equal(t, "customer request", req.Data.Attributes.StatusReason)
+
+				req.Data.Version++
+				json.NewEncoder(w).Encode(req)
+			}).Methods(http.MethodPatch)
+
+			mandate := &models.Mandate{ID: "mandate-id", Type: "mandates", Version: 3, Attributes: models.MandateAttributes{Status: test.givenStatus}}
+			m, _, err := client.Mandate.Cancel(context.TODO(), mandate, "customer request")
+			assert.Equal(t, test.expectedCall, isCalled)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				_, ok := err.(*InvalidTransitionError)
+				assert.True(t, ok)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, models.MandateCancelled, m.Attributes.Status)
+			assert.Equal(t, 4, m.Version)
+		})
+	}
+}
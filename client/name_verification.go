@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// NameVerifier verifies payee names. It is implemented by NameVerificationService and by
+// the local matcher in clienttest package.
+type NameVerifier interface {
+	Verify(ctx context.Context, verification *models.NameVerification) (*models.NameVerificationResult, *Response, error)
+}
+
+// NameVerificationService holds API functionality for Confirmation of Payee name verification.
+type NameVerificationService struct {
+	client *Client
+}
+
+var _ NameVerifier = (*NameVerificationService)(nil)
+
+// Verify submits name verification request and returns its result. Name, account number and bank ID
+// of the verification must be set.
+func (s *NameVerificationService) Verify(ctx context.Context, verification *models.NameVerification) (*models.NameVerificationResult, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPost, "v1/confirmation-of-payee/verifications", verification)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := &models.NameVerification{}
+	resp, err := s.client.Do(ctx, req, v)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &v.Attributes.Result, resp, nil
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameVerificationService_Verify(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenResponse   string
+		givenStatusCode int
+		expectedResult  *models.NameVerificationResult
+		expectedError   string
+	}{
+		{
+			name:            "it should return close match with suggested name",
+			givenResponse:   `{"data":{"id":"verification-id","attributes":{"result":{"match":"close_match","suggested_name":"Samantha Holder","reason_code":"MBAM"}}}}`,
+			givenStatusCode: http.StatusCreated,
+			expectedResult:  &models.NameVerificationResult{Match: models.NameCloseMatch, SuggestedName: "Samantha Holder", ReasonCode: "MBAM"},
+		},
+		{
+			name:            "it should return opted out result",
+			givenResponse:   `{"data":{"id":"verification-id","attributes":{"result":{"match":"opted_out","reason_code":"OPTO"}}}}`,
+			givenStatusCode: http.StatusCreated,
+			expectedResult:  &models.NameVerificationResult{Match: models.NameOptedOut, ReasonCode: "OPTO"},
+		},
+		{
+			name:            "it should return custom api error on bad request",
+			givenResponse:   `{"error_message":"name in body is required"}`,
+			givenStatusCode: http.StatusBadRequest,
+			expectedError:   "code: 400, message: name in body is required",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/confirmation-of-payee/verifications", func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Data models.NameVerification `json:"data"`
+				}
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "Samanta Holder", req.Data.Attributes.Name)
+				assert.Equal(t, "10000004", req.Data.Attributes.AccountNumber)
+
+				w.WriteHeader(test.givenStatusCode)
+				fmt.Fprint(w, test.givenResponse)
+			}).Methods(http.MethodPost)
+
+			verification := &models.NameVerification{
+				ID:   "verification-id",
+				Type: "name_verifications",
+				Attributes: models.NameVerificationAttributes{
+					Name:          "Samanta Holder",
+					BankID:        "400302",
+					AccountNumber: "10000004",
+				},
+			}
+			result, _, err := client.NameVerification.Verify(context.TODO(), verification)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// OrganisationService holds API functionality for organisation units API.
+type OrganisationService struct {
+	resources *ResourceService[models.Organisation]
+}
+
+// OrganisationFilter is a structure required to build query parameters for filtering organisations list.
+type OrganisationFilter struct {
+	Name     string `url:"name,omitempty"`
+	ParentID string `url:"parent_id,omitempty"`
+}
+
+// OrganisationListOptions specifies optional parameters for listing organisations.
+type OrganisationListOptions struct {
+	Pagination
+	Filter *OrganisationFilter `url:"filter,omitempty"`
+}
+
+// Create creates an organisation. Name must be specified.
+func (s *OrganisationService) Create(ctx context.Context, organisation *models.Organisation) (*models.Organisation, *Response, error) {
+	return s.resources.Create(ctx, organisation)
+}
+
+// Fetch a single organisation using the organisation ID.
+func (s *OrganisationService) Fetch(ctx context.Context, id string) (*models.Organisation, *Response, error) {
+	return s.resources.Fetch(ctx, id)
+}
+
+// List organisations with the ability to filter and page.
+func (s *OrganisationService) List(ctx context.Context, opts *OrganisationListOptions) ([]models.Organisation, *Response, error) {
+	return s.resources.List(ctx, opts)
+}
+
+// Children lists organisations whose parent is the organisation with given ID.
+func (s *OrganisationService) Children(ctx context.Context, id string, opts *Pagination) ([]models.Organisation, *Response, error) {
+	listOpts := &OrganisationListOptions{Filter: &OrganisationFilter{ParentID: id}}
+	if opts != nil {
+		listOpts.Pagination = *opts
+	}
+	return s.List(ctx, listOpts)
+}
+
+// Delete deletes given version of an organisation.
+func (s *OrganisationService) Delete(ctx context.Context, id string, version int) (*Response, error) {
+	return s.resources.Delete(ctx, id, version)
+}
+
+// GroupByOrganisation groups accounts, e.g. returned by AccountService.List, by their organisation ID.
+// Accounts keep their order within each group.
+func GroupByOrganisation(accounts []models.Account) map[string][]models.Account {
+	groups := make(map[string][]models.Account)
+	for _, acc := range accounts {
+		groups[acc.OrganisationID] = append(groups[acc.OrganisationID], acc)
+	}
+	return groups
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganisationService_Create(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/organisation/units", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data models.Organisation `json:"data"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "Retail", req.Data.Attributes.Name)
+		assert.Equal(t, "parent-id", req.Data.Attributes.ParentID)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(req)
+	}).Methods(http.MethodPost)
+
+	org, _, err := client.Organisation.Create(context.TODO(), &models.Organisation{
+		ID:         "organisation-id",
+		Type:       "organisations",
+		Attributes: models.OrganisationAttributes{Name: "Retail", ParentID: "parent-id"},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "organisation-id", org.ID)
+}
+
+func TestOrganisationService_Fetch(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenID       string
+		expectedName  string
+		expectedError string
+	}{
+		{
+			name:         "it should return organisation on valid response",
+			givenID:      "organisation-id",
+			expectedName: "Retail",
+		},
+		{
+			name:          "it should return custom api error when organisation does not exist",
+			givenID:       "missing",
+			expectedError: "code: 404, message: record missing does not exist",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/organisation/units/{id}", func(w http.ResponseWriter, r *http.Request) {
+				id := mux.Vars(r)["id"]
+				if id != "organisation-id" {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprintf(w, `{"error_message":"record %s does not exist"}`, id)
+					return
+				}
+				fmt.Fprint(w, `{"data":{"id":"organisation-id","type":"organisations","attributes":{"name":"Retail"}}}`)
+			}).Methods(http.MethodGet)
+
+			org, _, err := client.Organisation.Fetch(context.TODO(), test.givenID)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedName, org.Attributes.Name)
+		})
+	}
+}
+
+func TestOrganisationService_Children(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/organisation/units", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "parent-id", r.URL.Query().Get("filter[parent_id]"))
+		assert.Equal(t, "10", r.URL.Query().Get("page[size]"))
+		fmt.Fprint(w, `{"data":[{"id":"a","attributes":{"parent_id":"parent-id"}},{"id":"b","attributes":{"parent_id":"parent-id"}}]}`)
+	}).Methods(http.MethodGet)
+
+	children, _, err := client.Organisation.Children(context.TODO(), "parent-id", &Pagination{PerPage: 10})
+	require.Nil(t, err)
+	require.Len(t, children, 2)
+	assert.Equal(t, "b", children[1].ID)
+}
+
+func TestOrganisationService_Delete(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	var isCalled bool
+
+	router.HandleFunc("/v1/organisation/units/{id}", func(w http.ResponseWriter, r *http.Request) {
+		isCalled = true
+		assert.Equal(t, "organisation-id", mux.Vars(r)["id"])
+		assert.Equal(t, "2", r.URL.Query().Get("version"))
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodDelete)
+
+	_, err := client.Organisation.Delete(context.TODO(), "organisation-id", 2)
+	assert.Nil(t, err)
+	assert.True(t, isCalled)
+}
+
+func TestGroupByOrganisation(t *testing.T) {
+	groups := GroupByOrganisation([]models.Account{
+		{ID: "a", OrganisationID: "first"},
+		{ID: "b", OrganisationID: "second"},
+		{ID: "c", OrganisationID: "first"},
+	})
+	assert.Equal(t, map[string][]models.Account{
+		"first":  {{ID: "a", OrganisationID: "first"}, {ID: "c", OrganisationID: "first"}},
+		"second": {{ID: "b", OrganisationID: "second"}},
+	}, groups)
+}
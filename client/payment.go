@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// PaymentService holds API functionality for payments API.
+type PaymentService struct {
+	client    *Client
+	resources *ResourceService[models.Payment]
+
+	// Backoff configures polling of WaitForStatus. DefaultBackoff is used if it is not set.
+	Backoff Backoff
+}
+
+// PaymentFilter is a structure required to build query parameters for filtering payments list.
+type PaymentFilter struct {
+	Currency      string `url:"currency,omitempty"`
+	Amount        string `url:"amount,omitempty"`
+	PaymentScheme string `url:"payment_scheme,omitempty"`
+	// ProcessingDateFrom and ProcessingDateTo limit processing date, formatted as YYYY-MM-DD.
+	ProcessingDateFrom string `url:"processing_date_from,omitempty"`
+	ProcessingDateTo   string `url:"processing_date_to,omitempty"`
+}
+
+// PaymentListOptions specifies optional parameters for listing payments.
+type PaymentListOptions struct {
+	Pagination
+	Filter *PaymentFilter `url:"filter,omitempty"`
+}
+
+// Create creates a payment. Amount, currency, payment scheme and both parties must be specified.
+func (s *PaymentService) Create(ctx context.Context, payment *models.Payment) (*models.Payment, *Response, error) {
+	return s.resources.Create(ctx, payment)
+}
+
+// Fetch a single payment using the payment ID.
+func (s *PaymentService) Fetch(ctx context.Context, id string) (*models.Payment, *Response, error) {
+	return s.resources.Fetch(ctx, id)
+}
+
+// List payments with the ability to filter and page.
+func (s *PaymentService) List(ctx context.Context, opts *PaymentListOptions) ([]models.Payment, *Response, error) {
+	return s.resources.List(ctx, opts)
+}
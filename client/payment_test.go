@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentService_Create(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenResponse     string
+		givenStatusCode   int
+		expectedPaymentID string
+		expectedError     string
+	}{
+		{
+			name:              "it should return created payment on valid response",
+			givenResponse:     `{"data":{"id":"payment-id","type":"payments","attributes":{"amount":"100.21","currency":"GBP"}}}`,
+			givenStatusCode:   http.StatusCreated,
+			expectedPaymentID: "payment-id",
+		},
+		{
+			name:            "it should return custom api error on bad request",
+			givenResponse:   `{"error_message":"amount in body is required"}`,
+			givenStatusCode: http.StatusBadRequest,
+			expectedError:   "code: 400, message: amount in body is required",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/transaction/payments", func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Data models.Payment `json:"data"`
+				}
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "100.21", req.Data.Attributes.Amount)
+				assert.Equal(t, "12345678", req.Data.Attributes.BeneficiaryParty.AccountNumber)
+				assert.Equal(t, "400302", req.Data.Attributes.BeneficiaryParty.AccountWith.BankID)
+
+				w.WriteHeader(test.givenStatusCode)
+				fmt.Fprint(w, test.givenResponse)
+			}).Methods(http.MethodPost)
+
+			beneficiary := &models.Account{Attributes: models.AccountAttributes{BankID: "400302", BankIDCode: "GBDSC", AccountNumber: "12345678"}}
+			payment := &models.Payment{
+				ID:   "payment-id",
+				Type: "payments",
+				Attributes: models.PaymentAttributes{
+					Amount:           "100.21",
+					Currency:         "GBP",
+					PaymentScheme:    "FPS",
+					BeneficiaryParty: models.NewParty(beneficiary),
+				},
+			}
+			p, _, err := client.Payment.Create(context.TODO(), payment)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedPaymentID, p.ID)
+		})
+	}
+}
+
+func TestPaymentService_Fetch(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/transaction/payments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["id"] != "payment-id" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"error_message":"record %s does not exist"}`, mux.Vars(r)["id"])
+			return
+		}
+		fmt.Fprint(w, `{"data":{"id":"payment-id","attributes":{"amount":"1.00","currency":"GBP","debtor_party":{"account_number":"87654321"}}}}`)
+	}).Methods(http.MethodGet)
+
+	p, _, err := client.Payment.Fetch(context.TODO(), "payment-id")
+	require.Nil(t, err)
+	assert.Equal(t, "1.00", p.Attributes.Amount)
+	assert.Equal(t, "87654321", p.Attributes.DebtorParty.AccountNumber)
+
+	_, _, err = client.Payment.Fetch(context.TODO(), "missing")
+	assert.EqualError(t, err, "code: 404, message: record missing does not exist")
+}
+
+func TestPaymentService_List(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/transaction/payments", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "GBP", query.Get("filter[currency]"))
+		assert.Equal(t, "2019-10-01", query.Get("filter[processing_date_from]"))
+		assert.Equal(t, "1", query.Get("page[number]"))
+		assert.Equal(t, "2", query.Get("page[size]"))
+		fmt.Fprint(w, `{"data":[{"id":"a"},{"id":"b"}],"links":{"next":"/v1/transaction/payments?page[number]=2&page[size]=2"}}`)
+	}).Methods(http.MethodGet)
+
+	opts := &PaymentListOptions{
+		Pagination: Pagination{Page: 1, PerPage: 2},
+		Filter:     &PaymentFilter{Currency: "GBP", ProcessingDateFrom: "2019-10-01"},
+	}
+	payments, resp, err := client.Payment.List(context.TODO(), opts)
+	require.Nil(t, err)
+	require.Len(t, payments, 2)
+	assert.Equal(t, "b", payments[1].ID)
+	assert.False(t, resp.Links.IsLastPage())
+}
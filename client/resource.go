@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// ResourceService implements operations shared by JSON:API resources, e.g. models.Account, which are
+// held in a collection at given path. Services of particular resources wrap it and add resource specific
+// operations.
+type ResourceService[T any] struct {
+	client *Client
+	typ    string
+	path   string
+}
+
+// NewResourceService creates service for resources of JSON:API type typ, e.g. "accounts", held in collection
+// at path relative to client BaseURL, e.g. "v1/organisation/accounts".
+func NewResourceService[T any](client *Client, typ, path string) *ResourceService[T] {
+	return &ResourceService[T]{client: client, typ: typ, path: path}
+}
+
+// Type returns JSON:API type of the resources.
+func (s *ResourceService[T]) Type() string {
+	return s.typ
+}
+
+// Create creates resource. Type of the resource is set to the service type if it is empty.
+func (s *ResourceService[T]) Create(ctx context.Context, resource *T) (*T, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPost, s.path, withType(resource, s.typ))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := new(T)
+	resp, err := s.client.Do(ctx, req, created)
+	if err != nil {
+		return nil, resp, err
+	}
+	return created, resp, nil
+}
+
+// Fetch a single resource using its ID.
+func (s *ResourceService[T]) Fetch(ctx context.Context, id string) (*T, *Response, error) {
+	return s.FetchWithOptions(ctx, id, nil)
+}
+
+// FetchWithOptions fetches a single resource like Fetch does. Opts are encoded as query parameters.
+func (s *ResourceService[T]) FetchWithOptions(ctx context.Context, id string, opts interface{}) (*T, *Response, error) {
+	path, err := addOptions(s.resourcePath(id), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resource := new(T)
+	resp, err := s.client.Do(ctx, req, resource)
+	if err != nil {
+		return nil, resp, err
+	}
+	return resource, resp, nil
+}
+
+// List resources. Opts are encoded as query parameters, see AccountListOptions for example.
+func (s *ResourceService[T]) List(ctx context.Context, opts interface{}) ([]T, *Response, error) {
+	path, err := addOptions(s.path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resources []T
+	resp, err := s.client.Do(ctx, req, &resources)
+	if err != nil {
+		return nil, resp, err
+	}
+	return resources, resp, nil
+}
+
+// ListEach lists resources like List does, but decodes them one at a time while the response is read
+// and passes each of them to fn. Listing stops at the first error returned by fn.
+func (s *ResourceService[T]) ListEach(ctx context.Context, opts interface{}, fn func(*T) error) (*Response, error) {
+	path, err := addOptions(s.path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.streamPage(ctx, path, fn)
+}
+
+// ListAll pages through all resources starting at the page given in opts, following next links,
+// and passes every resource to fn. Options missing in next links, e.g. filters, are added to them.
+// Listing stops at the first error returned by fn.
+func (s *ResourceService[T]) ListAll(ctx context.Context, opts interface{}, fn func(*T) error) error {
+	path, err := addOptions(s.path, opts)
+	if err != nil {
+		return err
+	}
+
+	for path != "" {
+		resp, err := s.streamPage(ctx, path, fn)
+		if err != nil {
+			return err
+		}
+
+		path = ""
+		if !resp.Links.IsLastPage() {
+			if path, err = addMissingOptions(resp.Links.Next, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// streamPage requests a single page of resources at given path and streams them to fn.
+func (s *ResourceService[T]) streamPage(ctx context.Context, path string, fn func(*T) error) (*Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Stream(ctx, req, func(decode DecodeFunc) error {
+		resource := new(T)
+		if err := decode(resource); err != nil {
+			return err
+		}
+		return fn(resource)
+	})
+}
+
+// Update patches resource with given ID. Version of the resource must match its current version.
+func (s *ResourceService[T]) Update(ctx context.Context, id string, resource *T) (*T, *Response, error) {
+	return s.Patch(ctx, id, withType(resource, s.typ))
+}
+
+// Patch patches resource with given ID like Update does, but sends patch as it is, e.g. to set attributes
+// to null. Patch must hold type and version of the resource.
+func (s *ResourceService[T]) Patch(ctx context.Context, id string, patch interface{}) (*T, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, s.resourcePath(id), patch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(T)
+	resp, err := s.client.Do(ctx, req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+	return updated, resp, nil
+}
+
+// Delete deletes given version of resource with given ID. Response body is optional, but it must be
+// a valid document if it is present.
+func (s *ResourceService[T]) Delete(ctx context.Context, id string, version int) (*Response, error) {
+	path := fmt.Sprintf("%s?version=%d", s.resourcePath(id), version)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var data json.RawMessage
+	return s.client.Do(ctx, req, &data)
+}
+
+func (s *ResourceService[T]) resourcePath(id string) string {
+	return fmt.Sprintf("%s/%s", s.path, id)
+}
+
+// withType returns copy of resource with its Type field set to typ, if the field is empty.
+// Resource is returned as it is if it has no such field.
+func withType[T any](resource *T, typ string) *T {
+	if resource == nil {
+		return nil
+	}
+	field := reflect.ValueOf(resource).Elem()
+	if field.Kind() != reflect.Struct {
+		return resource
+	}
+	field = field.FieldByName("Type")
+	if !field.IsValid() || field.Kind() != reflect.String || field.String() != "" {
+		return resource
+	}
+
+	typed := *resource
+	reflect.ValueOf(&typed).Elem().FieldByName("Type").SetString(typ)
+	return &typed
+}
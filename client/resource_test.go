@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type widget struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+}
+
+type widgetListOptions struct {
+	Pagination
+	Filter *struct {
+		Colour string `url:"colour,omitempty"`
+	} `url:"filter,omitempty"`
+}
+
+func TestResourceService_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenWidget  *widget
+		expectedBody string
+	}{
+		{
+			name:         "it should set type of the resource when it is empty",
+			givenWidget:  &widget{ID: "widget-id"},
+			expectedBody: `{"data":{"id":"widget-id","type":"widgets","version":0}}`,
+		},
+		{
+			name:         "it should keep type of the resource when it is set",
+			givenWidget:  &widget{ID: "widget-id", Type: "gadgets"},
+			expectedBody: `{"data":{"id":"widget-id","type":"gadgets","version":0}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+			widgets := NewResourceService[widget](client, "widgets", "v1/widgets")
+
+			router.HandleFunc("/v1/widgets", func(w http.ResponseWriter, r *http.Request) {
+				data, err := ioutil.ReadAll(r.Body)
+				require.Nil(t, err)
+				assert.Equal(t, test.expectedBody+"\n", string(data))
+				w.WriteHeader(http.StatusCreated)
+				w.Write(data)
+			}).Methods(http.MethodPost)
+
+			givenType := test.givenWidget.Type
+			created, _, err := widgets.Create(context.TODO(), test.givenWidget)
+			require.Nil(t, err)
+			assert.Equal(t, "widget-id", created.ID)
+			assert.Equal(t, givenType, test.givenWidget.Type, "it should not modify given resource")
+		})
+	}
+}
+
+func TestResourceService_FetchUpdateDelete(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	widgets := NewResourceService[widget](client, "widgets", "v1/widgets")
+	var deleted bool
+
+	router.HandleFunc("/v1/widgets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintf(w, `{"data":{"id":"%s","type":"widgets","version":1}}`, id)
+		case http.MethodPatch:
+			fmt.Fprintf(w, `{"data":{"id":"%s","type":"widgets","version":2}}`, id)
+		case http.MethodDelete:
+			deleted = true
+			assert.Equal(t, "2", r.URL.Query().Get("version"))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	fetched, _, err := widgets.Fetch(context.TODO(), "widget-id")
+	require.Nil(t, err)
+	assert.Equal(t, &widget{ID: "widget-id", Type: "widgets", Version: 1}, fetched)
+
+	updated, _, err := widgets.Update(context.TODO(), fetched.ID, fetched)
+	require.Nil(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	_, err = widgets.Delete(context.TODO(), updated.ID, updated.Version)
+	require.Nil(t, err)
+	assert.True(t, deleted)
+}
+
+func TestResourceService_List(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	widgets := NewResourceService[widget](client, "widgets", "v1/widgets")
+
+	router.HandleFunc("/v1/widgets", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "red", r.URL.Query().Get("filter[colour]"))
+		if r.URL.Query().Get("page[number]") == "1" {
+			fmt.Fprint(w, `{"data":[{"id":"c"}],"links":{"prev":"/v1/widgets?filter[colour]=red&page[number]=0"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"a"},{"id":"b"}],"links":{"next":"/v1/widgets?filter[colour]=red&page[number]=1"}}`)
+	}).Methods(http.MethodGet)
+
+	opts := &widgetListOptions{}
+	opts.Filter = &struct {
+		Colour string `url:"colour,omitempty"`
+	}{Colour: "red"}
+
+	page, _, err := widgets.List(context.TODO(), opts)
+	require.Nil(t, err)
+	assert.Len(t, page, 2)
+
+	var ids []string
+	err = widgets.ListAll(context.TODO(), opts, func(w *widget) error {
+		ids = append(ids, w.ID)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+}
@@ -6,6 +6,17 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
+)
+
+const (
+	headerRequestID          = "X-Request-Id"
+	headerCorrelationID      = "X-Correlation-Id"
+	headerRateLimitLimit     = "X-Ratelimit-Limit"
+	headerRateLimitRemaining = "X-Ratelimit-Remaining"
+	headerRateLimitReset     = "X-Ratelimit-Reset"
+	headerETag               = "ETag"
+	headerLastModified       = "Last-Modified"
 )
 
 // Links holds information about response pagination.
@@ -45,20 +56,66 @@ func (l *Links) IsLastPage() bool {
 	return l.Next == ""
 }
 
+// Rate represents rate limit reported by API in response headers.
+type Rate struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time when the current window resets.
+	Reset time.Time
+}
+
 // Response is API HTTP response.
 type Response struct {
 	Response *http.Response
 	Data     json.RawMessage `json:"data"`
 	Links    Links           `json:"links,omitempty"`
+	Meta     json.RawMessage `json:"meta,omitempty"`
+	// Included holds resources included in compound document, see Included and Resolve.
+	Included []json.RawMessage `json:"included,omitempty"`
+
+	// RequestID is request or correlation ID assigned by the server, useful to trace requests in support tickets.
+	RequestID string `json:"-"`
+	// Rate holds rate limit headers of the response, if any.
+	Rate Rate `json:"-"`
+	// ETag is entity tag of returned resource.
+	ETag string `json:"-"`
+	// LastModified is time when returned resource was last modified, zero if unknown.
+	LastModified time.Time `json:"-"`
+	// Duration is time it took to send the request and receive the response.
+	Duration time.Duration `json:"-"`
+	// FromCache reports whether response was served from Cache.
+	FromCache bool `json:"-"`
 }
 
 // NewResponse creates a new Response for the provided http.Response
 func NewResponse(r *http.Response) *Response {
 	response := Response{Response: r}
+	if r == nil {
+		return &response
+	}
+
+	response.RequestID = requestID(r.Header)
+	response.Rate = parseRate(r.Header)
+	response.ETag = r.Header.Get(headerETag)
+	response.FromCache = r.Header.Get(headerCache) != ""
+	if lm, err := http.ParseTime(r.Header.Get(headerLastModified)); err == nil {
+		response.LastModified = lm
+	}
 
 	return &response
 }
 
+// DecodeMeta decodes JSON:API meta object of the response into the value pointed to by v.
+// It does nothing if response has no meta object.
+func (r *Response) DecodeMeta(v interface{}) error {
+	if len(r.Meta) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Meta, v)
+}
+
 // ErrorResponse is a custom error structure for API errors.
 // It hold HTTP response that caused error and has all given details about an error.
 type ErrorResponse struct {
@@ -66,13 +123,40 @@ type ErrorResponse struct {
 	StatusCode int
 	Code       string `json:"error_code"`
 	Message    string `json:"error_message"`
+	RequestID  string `json:"-"`
 }
 
 // Error is required to be implemented to meet error interface
 func (e *ErrorResponse) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("code: %d, message: %s, request id: %s", e.StatusCode, e.Message, e.RequestID)
+	}
 	return fmt.Sprintf("code: %d, message: %s", e.StatusCode, e.Message)
 }
 
+// requestID returns request ID from given headers, falling back to correlation ID.
+func requestID(h http.Header) string {
+	if id := h.Get(headerRequestID); id != "" {
+		return id
+	}
+	return h.Get(headerCorrelationID)
+}
+
+// parseRate parses rate limit headers. Missing or malformed headers are left as zero values.
+func parseRate(h http.Header) Rate {
+	var rate Rate
+	if limit, err := strconv.Atoi(h.Get(headerRateLimitLimit)); err == nil {
+		rate.Limit = limit
+	}
+	if remaining, err := strconv.Atoi(h.Get(headerRateLimitRemaining)); err == nil {
+		rate.Remaining = remaining
+	}
+	if reset, err := strconv.ParseInt(h.Get(headerRateLimitReset), 10, 64); err == nil {
+		rate.Reset = time.Unix(reset, 0)
+	}
+	return rate
+}
+
 func pageForURL(urlText string) (int, error) {
 	u, err := url.ParseRequestURI(urlText)
 	if err != nil {
@@ -3,6 +3,7 @@ package client
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,6 +134,16 @@ func TestErrorResponse_Error(t *testing.T) {
 			},
 			expectedMessage: "code: 500, message: error message",
 		},
+		{
+			name: "it should include request id when it is known",
+			givenError: &ErrorResponse{
+				Response:   nil,
+				StatusCode: http.StatusNotFound,
+				Message:    "not found",
+				RequestID:  "req-1",
+			},
+			expectedMessage: "code: 404, message: not found, request id: req-1",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -144,3 +155,68 @@ func TestErrorResponse_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResponse(t *testing.T) {
+	lastModified := time.Date(2019, 10, 2, 13, 34, 32, 0, time.UTC)
+	tests := []struct {
+		name             string
+		givenHeader      http.Header
+		expectedResponse Response
+	}{
+		{
+			name:             "it should leave metadata empty when no headers are given",
+			givenHeader:      http.Header{},
+			expectedResponse: Response{},
+		},
+		{
+			name: "it should read request id, rate limits, etag and last modified headers",
+			givenHeader: http.Header{
+				"X-Request-Id":          []string{"req-1"},
+				"X-Ratelimit-Limit":     []string{"100"},
+				"X-Ratelimit-Remaining": []string{"99"},
+				"X-Ratelimit-Reset":     []string{"1570023272"},
+				"Etag":                  []string{`"v1"`},
+				"Last-Modified":         []string{lastModified.Format(http.TimeFormat)},
+			},
+			expectedResponse: Response{
+				RequestID:    "req-1",
+				Rate:         Rate{Limit: 100, Remaining: 99, Reset: time.Unix(1570023272, 0)},
+				ETag:         `"v1"`,
+				LastModified: lastModified,
+			},
+		},
+		{
+			name: "it should fall back to correlation id and ignore malformed headers",
+			givenHeader: http.Header{
+				"X-Correlation-Id":  []string{"corr-1"},
+				"X-Ratelimit-Limit": []string{"many"},
+				"Last-Modified":     []string{"yesterday"},
+			},
+			expectedResponse: Response{
+				RequestID: "corr-1",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			httpResp := &http.Response{Header: test.givenHeader}
+			test.expectedResponse.Response = httpResp
+
+			assert.Equal(t, &test.expectedResponse, NewResponse(httpResp))
+		})
+	}
+}
+
+func TestResponse_DecodeMeta(t *testing.T) {
+	meta := struct {
+		Count int `json:"count"`
+	}{}
+
+	r := &Response{}
+	require.Nil(t, r.DecodeMeta(&meta))
+	assert.Equal(t, 0, meta.Count)
+
+	r.Meta = []byte(`{"count":42}`)
+	require.Nil(t, r.DecodeMeta(&meta))
+	assert.Equal(t, 42, meta.Count)
+}
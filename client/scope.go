@@ -0,0 +1,256 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// ErrOrganisationMismatch is returned by organisation scoped services for accounts which belong to another organisation.
+var ErrOrganisationMismatch = errors.New("account belongs to another organisation")
+
+// OrganisationScope is a view of Client limited to a single organisation.
+type OrganisationScope struct {
+	OrganisationID string
+
+	Account *ScopedAccountService
+}
+
+// ForOrganisation returns a view of the client scoped to the organisation with given ID.
+func (c *Client) ForOrganisation(id string) *OrganisationScope {
+	return &OrganisationScope{
+		OrganisationID: id,
+		Account:        &ScopedAccountService{organisationID: id, accounts: c.Account},
+	}
+}
+
+// ScopedAccountService is AccountAPI limited to accounts of a single organisation.
+// Created and updated accounts get the organisation ID when it is not set, lists are filtered by it,
+// and accounts of other organisations are rejected with ErrOrganisationMismatch or skipped when listing.
+type ScopedAccountService struct {
+	organisationID string
+	accounts       AccountAPI
+}
+
+var _ AccountAPI = (*ScopedAccountService)(nil)
+
+// Create creates account in the organisation.
+func (s *ScopedAccountService) Create(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
+	scoped, err := s.scope(account)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.accounts.Create(ctx, scoped)
+}
+
+// Fetch fetches account, failing with ErrOrganisationMismatch if it belongs to another organisation.
+func (s *ScopedAccountService) Fetch(ctx context.Context, id string) (*models.Account, *Response, error) {
+	return s.FetchWithOptions(ctx, id, nil)
+}
+
+// FetchWithOptions fetches account like Fetch does, e.g. including its related resources.
+func (s *ScopedAccountService) FetchWithOptions(ctx context.Context, id string, opts *AccountFetchOptions) (*models.Account, *Response, error) {
+	acc, resp, err := s.accounts.FetchWithOptions(ctx, id, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	if err := s.check(acc); err != nil {
+		return nil, resp, err
+	}
+	return acc, resp, nil
+}
+
+// List lists accounts of the organisation.
+func (s *ScopedAccountService) List(ctx context.Context, pagination *Pagination) ([]models.Account, *Response, error) {
+	return s.ListWithOptions(ctx, paginationOptions(pagination))
+}
+
+// ListWithOptions lists accounts of the organisation matching opts.
+func (s *ScopedAccountService) ListWithOptions(ctx context.Context, opts *AccountListOptions) ([]models.Account, *Response, error) {
+	accounts, resp, err := s.accounts.ListWithOptions(ctx, s.listOptions(opts))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	scoped := accounts[:0]
+	for _, acc := range accounts {
+		if acc.OrganisationID == s.organisationID {
+			scoped = append(scoped, acc)
+		}
+	}
+	return scoped, resp, nil
+}
+
+// ListEach lists accounts of the organisation one at a time.
+func (s *ScopedAccountService) ListEach(ctx context.Context, pagination *Pagination, fn func(*models.Account) error) (*Response, error) {
+	return s.ListEachWithOptions(ctx, paginationOptions(pagination), fn)
+}
+
+// ListEachWithOptions lists accounts of the organisation matching opts one at a time.
+func (s *ScopedAccountService) ListEachWithOptions(ctx context.Context, opts *AccountListOptions, fn func(*models.Account) error) (*Response, error) {
+	return s.accounts.ListEachWithOptions(ctx, s.listOptions(opts), s.skipForeign(fn))
+}
+
+// ListAll lists all pages of accounts of the organisation.
+func (s *ScopedAccountService) ListAll(ctx context.Context, opts *AccountListOptions, fn func(*models.Account) error) error {
+	return s.accounts.ListAll(ctx, s.listOptions(opts), s.skipForeign(fn))
+}
+
+// ListPartial lists accounts of the organisation matching opts, tracking which fields were returned.
+func (s *ScopedAccountService) ListPartial(ctx context.Context, opts *AccountListOptions) ([]models.PartialAccount, *Response, error) {
+	accounts, resp, err := s.accounts.ListPartial(ctx, s.listOptions(opts))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	scoped := accounts[:0]
+	for _, acc := range accounts {
+		if acc.OrganisationID == s.organisationID {
+			scoped = append(scoped, acc)
+		}
+	}
+	return scoped, resp, nil
+}
+
+// ListAllPartial lists all pages of accounts of the organisation, tracking which fields were returned.
+func (s *ScopedAccountService) ListAllPartial(ctx context.Context, opts *AccountListOptions, fn func(*models.PartialAccount) error) error {
+	return s.accounts.ListAllPartial(ctx, s.listOptions(opts), func(acc *models.PartialAccount) error {
+		if acc.OrganisationID != s.organisationID {
+			return nil
+		}
+		return fn(acc)
+	})
+}
+
+// Update updates account of the organisation.
+// The account is fetched first to make sure the stored account belongs to the organisation too.
+func (s *ScopedAccountService) Update(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
+	scoped, resp, err := s.scopeStored(ctx, account)
+	if err != nil {
+		return nil, resp, err
+	}
+	return s.accounts.Update(ctx, scoped)
+}
+
+// Close closes account of the organisation, see AccountService.Close.
+func (s *ScopedAccountService) Close(ctx context.Context, account *models.Account, reason string) (*models.Account, *Response, error) {
+	scoped, resp, err := s.scopeStored(ctx, account)
+	if err != nil {
+		return nil, resp, err
+	}
+	return s.accounts.Close(ctx, scoped, reason)
+}
+
+// Reopen reopens closed account of the organisation, see AccountService.Reopen.
+func (s *ScopedAccountService) Reopen(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
+	scoped, resp, err := s.scopeStored(ctx, account)
+	if err != nil {
+		return nil, resp, err
+	}
+	return s.accounts.Reopen(ctx, scoped)
+}
+
+// Switch marks account of the organisation as switched, see AccountService.Switch.
+func (s *ScopedAccountService) Switch(ctx context.Context, account *models.Account, switched models.SwitchedAccount) (*models.Account, *Response, error) {
+	scoped, resp, err := s.scopeStored(ctx, account)
+	if err != nil {
+		return nil, resp, err
+	}
+	return s.accounts.Switch(ctx, scoped, switched)
+}
+
+// Delete deletes account of the organisation.
+func (s *ScopedAccountService) Delete(ctx context.Context, id string) (*Response, error) {
+	return s.DeleteVersion(ctx, id, 0)
+}
+
+// DeleteVersion deletes given version of account of the organisation.
+// The account is fetched first to make sure it belongs to the organisation.
+func (s *ScopedAccountService) DeleteVersion(ctx context.Context, id string, version int) (*Response, error) {
+	if _, resp, err := s.Fetch(ctx, id); err != nil {
+		return resp, err
+	}
+	return s.accounts.DeleteVersion(ctx, id, version)
+}
+
+// Watch watches accounts of the organisation.
+func (s *ScopedAccountService) Watch(ctx context.Context, interval time.Duration, filter *AccountFilter) <-chan AccountEvent {
+	source := s.accounts.Watch(ctx, interval, s.filter(filter))
+	events := make(chan AccountEvent)
+	go func() {
+		defer close(events)
+		for event := range source {
+			if event.Type != AccountWatchError && event.Account.OrganisationID != s.organisationID {
+				continue
+			}
+			if !sendAccountEvent(ctx, events, event) {
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// scope returns copy of account with organisation ID set, or error if it belongs to another organisation.
+func (s *ScopedAccountService) scope(account *models.Account) (*models.Account, error) {
+	scoped := *account
+	if scoped.OrganisationID == "" {
+		scoped.OrganisationID = s.organisationID
+	}
+	if err := s.check(&scoped); err != nil {
+		return nil, err
+	}
+	return &scoped, nil
+}
+
+// scopeStored scopes account like scope does and fetches it to make sure the stored account belongs
+// to the organisation too.
+func (s *ScopedAccountService) scopeStored(ctx context.Context, account *models.Account) (*models.Account, *Response, error) {
+	scoped, err := s.scope(account)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, resp, err := s.Fetch(ctx, account.ID); err != nil {
+		return nil, resp, err
+	}
+	return scoped, nil, nil
+}
+
+func (s *ScopedAccountService) check(account *models.Account) error {
+	if account.OrganisationID != s.organisationID {
+		return fmt.Errorf("account %s of organisation %s: %w", account.ID, account.OrganisationID, ErrOrganisationMismatch)
+	}
+	return nil
+}
+
+// listOptions returns copy of opts with organisation filter set.
+func (s *ScopedAccountService) listOptions(opts *AccountListOptions) *AccountListOptions {
+	scoped := &AccountListOptions{}
+	if opts != nil {
+		*scoped = *opts
+	}
+	scoped.Filter = s.filter(scoped.Filter)
+	return scoped
+}
+
+// filter returns copy of filter with organisation ID set.
+func (s *ScopedAccountService) filter(filter *AccountFilter) *AccountFilter {
+	scoped := &AccountFilter{}
+	if filter != nil {
+		*scoped = *filter
+	}
+	scoped.OrganisationID = s.organisationID
+	return scoped
+}
+
+func (s *ScopedAccountService) skipForeign(fn func(*models.Account) error) func(*models.Account) error {
+	return func(acc *models.Account) error {
+		if acc.OrganisationID != s.organisationID {
+			return nil
+		}
+		return fn(acc)
+	}
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopedAccountService_Create(t *testing.T) {
+	tests := []struct {
+		name                   string
+		givenOrganisationID    string
+		expectedOrganisationID string
+		expectedError          error
+	}{
+		{
+			name:                   "it should inject organisation id",
+			givenOrganisationID:    "",
+			expectedOrganisationID: "organisation-id",
+		},
+		{
+			name:                   "it should keep matching organisation id",
+			givenOrganisationID:    "organisation-id",
+			expectedOrganisationID: "organisation-id",
+		},
+		{
+			name:                "it should reject account of another organisation",
+			givenOrganisationID: "other-id",
+			expectedError:       ErrOrganisationMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Data models.Account `json:"data"`
+				}
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, test.expectedOrganisationID, req.Data.OrganisationID)
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(req)
+			}).Methods(http.MethodPost)
+
+			account := &models.Account{ID: "account-id", OrganisationID: test.givenOrganisationID}
+			acc, _, err := client.ForOrganisation("organisation-id").Account.Create(context.TODO(), account)
+			if test.expectedError != nil {
+				assert.True(t, errors.Is(err, test.expectedError), err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedOrganisationID, acc.OrganisationID)
+			assert.Equal(t, test.givenOrganisationID, account.OrganisationID)
+		})
+	}
+}
+
+func TestScopedAccountService_Fetch(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"id":"account-id","organisation_id":"other-id"}}`)
+	}).Methods(http.MethodGet)
+
+	scoped := client.ForOrganisation("organisation-id").Account
+	_, _, err := scoped.Fetch(context.TODO(), "account-id")
+	assert.True(t, errors.Is(err, ErrOrganisationMismatch))
+	assert.EqualError(t, err, "account account-id of organisation other-id: account belongs to another organisation")
+
+	_, _, err = scoped.FetchWithOptions(context.TODO(), "account-id", &AccountFetchOptions{Include: []string{IncludeMasterAccount}})
+	assert.True(t, errors.Is(err, ErrOrganisationMismatch))
+
+	_, err = scoped.DeleteVersion(context.TODO(), "account-id", 0)
+	assert.True(t, errors.Is(err, ErrOrganisationMismatch))
+
+	acc, _, err := client.ForOrganisation("other-id").Account.Fetch(context.TODO(), "account-id")
+	require.Nil(t, err)
+	assert.Equal(t, "account-id", acc.ID)
+}
+
+func TestScopedAccountService_Update(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"id":"account-id","organisation_id":"other-id"}}`)
+	}).Methods(http.MethodGet)
+	isCalled := false
+	router.HandleFunc("/v1/organisation/accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		isCalled = true
+	}).Methods(http.MethodPatch)
+
+	account := &models.Account{ID: "account-id", OrganisationID: "organisation-id"}
+	scoped := client.ForOrganisation("organisation-id").Account
+	_, _, err := scoped.Update(context.TODO(), account)
+	assert.True(t, errors.Is(err, ErrOrganisationMismatch))
+	_, _, err = scoped.Close(context.TODO(), account, "customer request")
+	assert.True(t, errors.Is(err, ErrOrganisationMismatch))
+	_, _, err = scoped.Switch(context.TODO(), account, models.SwitchedAccount{AccountNumber: "10000004"})
+	assert.True(t, errors.Is(err, ErrOrganisationMismatch))
+	assert.False(t, isCalled, "it should not update stored account of another organisation")
+}
+
+func TestScopedAccountService_List(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "organisation-id", r.URL.Query().Get("filter[organisation_id]"))
+		assert.Equal(t, "GB", r.URL.Query().Get("filter[country]"))
+		fmt.Fprint(w, `{"data":[{"id":"a","organisation_id":"organisation-id"},{"id":"b","organisation_id":"other-id"}]}`)
+	}).Methods(http.MethodGet)
+
+	opts := &AccountListOptions{Filter: &AccountFilter{Country: "GB"}}
+	scoped := client.ForOrganisation("organisation-id").Account
+
+	accounts, _, err := scoped.ListWithOptions(context.TODO(), opts)
+	require.Nil(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, "a", accounts[0].ID)
+	assert.Empty(t, opts.Filter.OrganisationID)
+
+	var ids []string
+	err = scoped.ListAll(context.TODO(), opts, func(acc *models.Account) error {
+		ids = append(ids, acc.ID)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a"}, ids)
+
+	partials, _, err := scoped.ListPartial(context.TODO(), opts)
+	require.Nil(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, "a", partials[0].ID)
+
+	ids = nil
+	err = scoped.ListAllPartial(context.TODO(), opts, func(acc *models.PartialAccount) error {
+		ids = append(ids, acc.ID)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a"}, ids)
+}
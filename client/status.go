@@ -0,0 +1,17 @@
+package client
+
+import "fmt"
+
+// InvalidTransitionError is returned when a resource cannot change from its current status to requested one.
+// It is returned before any request is sent.
+type InvalidTransitionError struct {
+	Resource string
+	ID       string
+	From     string
+	To       string
+}
+
+// Error is required to be implemented to meet error interface
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("%s %s cannot change status from %q to %q", e.Resource, e.ID, e.From, e.To)
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DecodeFunc decodes a single element of a streamed "data" array into the value pointed to by v.
+type DecodeFunc func(v interface{}) error
+
+// Stream sends an API request and decodes the response body as it is read, without buffering it.
+// Elements of the "data" array are passed one at a time to fn, which should call given DecodeFunc
+// once to decode the element. Elements which fn does not decode are skipped.
+// Returned Response has Links, Meta and Included populated, but Data is left empty.
+// Decoding stops at the first error returned by fn and that error is returned.
+func (c *Client) Stream(ctx context.Context, req *http.Request, fn func(decode DecodeFunc) error) (*Response, error) {
+	req = req.WithContext(ctx)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	r := NewResponse(resp)
+	r.Duration = time.Since(start)
+	err = checkResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	err = decodeStream(json.NewDecoder(resp.Body), r, fn)
+	return r, err
+}
+
+// decodeStream walks top level object of JSON:API document and decodes "data" elements using fn.
+func decodeStream(dec *json.Decoder, r *Response, fn func(decode DecodeFunc) error) error {
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected JSON object, but got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case "data":
+			err = decodeStreamData(dec, fn)
+		case "links":
+			err = dec.Decode(&r.Links)
+		case "meta":
+			err = dec.Decode(&r.Meta)
+		case "included":
+			err = dec.Decode(&r.Included)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// decodeStreamData decodes "data" member which is expected to be an array or null.
+func decodeStreamData(dec *json.Decoder, fn func(decode DecodeFunc) error) error {
+	tok, err := dec.Token()
+	if err != nil || tok == nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected data to be an array, but got %v", tok)
+	}
+
+	for dec.More() {
+		var decoded bool
+		err := fn(func(v interface{}) error {
+			decoded = true
+			return dec.Decode(v)
+		})
+		if err != nil {
+			return err
+		}
+		if !decoded {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	// consume closing bracket
+	_, err = dec.Token()
+	return err
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenResponse   string
+		givenStatusCode int
+		expectedIDs     []string
+		expectedNext    string
+		expectedError   string
+	}{
+		{
+			name:            "it should decode every element of data array",
+			givenResponse:   `{"data":[{"id":"a"},{"id":"b"}],"links":{"next":"/next"}}`,
+			givenStatusCode: http.StatusOK,
+			expectedIDs:     []string{"a", "b"},
+			expectedNext:    "/next",
+		},
+		{
+			name:            "it should skip unknown top level members",
+			givenResponse:   `{"meta":{"count":1},"data":[{"id":"a"}],"included":[{"id":"x"}]}`,
+			givenStatusCode: http.StatusOK,
+			expectedIDs:     []string{"a"},
+		},
+		{
+			name:            "it should not call callback on null data",
+			givenResponse:   `{"data":null}`,
+			givenStatusCode: http.StatusOK,
+		},
+		{
+			name:            "it should not fail on empty response",
+			givenStatusCode: http.StatusNoContent,
+		},
+		{
+			name:            "it should return an error when data is not an array",
+			givenResponse:   `{"data":{"id":"a"}}`,
+			givenStatusCode: http.StatusOK,
+			expectedError:   "expected data to be an array, but got {",
+		},
+		{
+			name:            "it should return an error on malformed response",
+			givenResponse:   `not-a-json`,
+			givenStatusCode: http.StatusOK,
+			expectedError:   "invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:            "it should return custom api error on internal server error status",
+			givenResponse:   `{"error_message": "custom error message"}`,
+			givenStatusCode: http.StatusInternalServerError,
+			expectedError:   "code: 500, message: custom error message",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.givenStatusCode)
+				fmt.Fprint(w, test.givenResponse)
+			}).Methods(http.MethodGet)
+
+			req, err := client.NewRequest(context.TODO(), http.MethodGet, "/", nil)
+			require.Nil(t, err)
+
+			var ids []string
+			resp, err := client.Stream(context.TODO(), req, func(decode DecodeFunc) error {
+				item := struct {
+					ID string `json:"id"`
+				}{}
+				if err := decode(&item); err != nil {
+					return err
+				}
+				ids = append(ids, item.ID)
+				return nil
+			})
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			if assert.Nil(t, err) {
+				assert.Equal(t, test.expectedIDs, ids)
+				assert.Equal(t, test.expectedNext, resp.Links.Next)
+			}
+		})
+	}
+}
+
+func TestStream_CallbackError(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"a"},{"id":"b"}]}`)
+	}).Methods(http.MethodGet)
+
+	req, _ := client.NewRequest(context.TODO(), http.MethodGet, "/", nil)
+	calls := 0
+	stop := errors.New("stop")
+	_, err := client.Stream(context.TODO(), req, func(decode DecodeFunc) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
+
+// staticTransport serves the same body for every request without touching the network.
+type staticTransport struct {
+	body []byte
+}
+
+func (t *staticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       ioutil.NopCloser(bytes.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func benchmarkListBody(b *testing.B, count int) []byte {
+	accounts := make([]models.Account, count)
+	for i := range accounts {
+		accounts[i] = models.Account{
+			ID:             fmt.Sprintf("bdf9e1a8-481e-483f-b54c-%012d", i),
+			OrganisationID: "7c3d20ff-ed78-45c4-aae0-0184cf6d3060",
+			Type:           "accounts",
+			Attributes: models.AccountAttributes{
+				Country:               "GB",
+				BaseCurrency:          "GBP",
+				AccountNumber:         "10000004",
+				BankID:                "400302",
+				BankIDCode:            "GBDSC",
+				Bic:                   "NWBKGB42",
+				Iban:                  "GB28NWBK40030212764204",
+				FirstName:             "Mary-Jane Doe",
+				AccountClassification: "Personal",
+			},
+		}
+	}
+	body, err := json.Marshal(struct {
+		Data  []models.Account `json:"data"`
+		Links Links            `json:"links"`
+	}{Data: accounts})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return body
+}
+
+func BenchmarkAccountService_List(b *testing.B) {
+	u, _ := url.Parse("http://example.com")
+	client := NewClient(&http.Client{Transport: &staticTransport{body: benchmarkListBody(b, 1000)}}, u)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		accs, _, err := client.Account.List(context.TODO(), nil)
+		if err != nil || len(accs) != 1000 {
+			b.Fatalf("unexpected result: %d accounts, %v", len(accs), err)
+		}
+	}
+}
+
+func BenchmarkAccountService_ListEach(b *testing.B) {
+	u, _ := url.Parse("http://example.com")
+	client := NewClient(&http.Client{Transport: &staticTransport{body: benchmarkListBody(b, 1000)}}, u)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		_, err := client.Account.ListEach(context.TODO(), nil, func(*models.Account) error {
+			count++
+			return nil
+		})
+		if err != nil || count != 1000 {
+			b.Fatalf("unexpected result: %d accounts, %v", count, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Backoff configures delays between polls. Delay starts at Initial and is multiplied by Multiplier
+// after every poll, up to Max. Multiplier less than 1 is replaced by the multiplier of DefaultBackoff.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// DefaultBackoff is used by WaitForStatus when PaymentService.Backoff is not set.
+var DefaultBackoff = Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second, Multiplier: 2}
+
+// next returns delay following given one.
+func (b Backoff) next(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return b.Initial
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultBackoff.Multiplier
+	}
+	delay = time.Duration(float64(delay) * multiplier)
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+	return delay
+}
+
+// SubmissionStatusError is returned by WaitForStatus when submission reaches a terminal status
+// other than the awaited ones.
+type SubmissionStatusError struct {
+	Submission *models.PaymentSubmission
+}
+
+// Error is required to be implemented to meet error interface
+func (e *SubmissionStatusError) Error() string {
+	attrs := e.Submission.Attributes
+	if attrs.StatusReason != "" {
+		return fmt.Sprintf("submission %s ended with status %s: %s", e.Submission.ID, attrs.Status, attrs.StatusReason)
+	}
+	return fmt.Sprintf("submission %s ended with status %s", e.Submission.ID, attrs.Status)
+}
+
+// CreateSubmission submits payment with given ID to the payment scheme.
+func (s *PaymentService) CreateSubmission(ctx context.Context, paymentID string, submission *models.PaymentSubmission) (*models.PaymentSubmission, *Response, error) {
+	return s.submissions(paymentID).Create(ctx, submission)
+}
+
+// FetchSubmission fetches a single submission of a payment.
+func (s *PaymentService) FetchSubmission(ctx context.Context, paymentID, submissionID string) (*models.PaymentSubmission, *Response, error) {
+	return s.submissions(paymentID).Fetch(ctx, submissionID)
+}
+
+// submissions returns service of submissions of payment with given ID.
+func (s *PaymentService) submissions(paymentID string) *ResourceService[models.PaymentSubmission] {
+	return NewResourceService[models.PaymentSubmission](s.client, "payment_submissions", fmt.Sprintf("v1/transaction/payments/%s/submissions", paymentID))
+}
+
+// WaitForStatus polls submission with backoff until it has one of given statuses, or any terminal status
+// if none are given. If submission reaches a terminal status which was not awaited, it is returned together
+// with *SubmissionStatusError. Polling stops with error of ctx when it is done, or when fetching fails.
+func (s *PaymentService) WaitForStatus(ctx context.Context, paymentID, submissionID string, statuses ...models.SubmissionStatus) (*models.PaymentSubmission, error) {
+	backoff := s.Backoff
+	if backoff.Initial <= 0 {
+		backoff = DefaultBackoff
+	}
+
+	var delay time.Duration
+	for {
+		sub, _, err := s.FetchSubmission(ctx, paymentID, submissionID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		status := sub.Attributes.Status
+		for _, want := range statuses {
+			if status == want {
+				return sub, nil
+			}
+		}
+		if status.Terminal() {
+			if len(statuses) == 0 {
+				return sub, nil
+			}
+			return sub, &SubmissionStatusError{Submission: sub}
+		}
+
+		delay = backoff.next(delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentService_CreateSubmission(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/transaction/payments/payment-id/submissions", func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"data":{"id":"submission-id","organisation_id":"","type":"payment_submissions","version":0,"attributes":{}}}`, string(data))
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"submission-id","type":"payment_submissions","attributes":{"status":"accepted"}}}`)
+	}).Methods(http.MethodPost)
+
+	sub, _, err := client.Payment.CreateSubmission(context.TODO(), "payment-id", &models.PaymentSubmission{ID: "submission-id"})
+	require.Nil(t, err)
+	assert.Equal(t, models.SubmissionAccepted, sub.Attributes.Status)
+}
+
+func TestPaymentService_WaitForStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenStatuses  []string
+		givenAwaited   []models.SubmissionStatus
+		givenTimeout   time.Duration
+		expectedStatus models.SubmissionStatus
+		expectedPolls  int
+		expectedError  string
+	}{
+		{
+			name:           "it should wait for terminal status",
+			givenStatuses:  []string{"accepted", "released", "delivery_confirmed"},
+			givenTimeout:   time.Second,
+			expectedStatus: models.SubmissionDeliveryConfirmed,
+			expectedPolls:  3,
+		},
+		{
+			name:           "it should wait for awaited status",
+			givenStatuses:  []string{"accepted", "released", "delivery_confirmed"},
+			givenAwaited:   []models.SubmissionStatus{models.SubmissionReleased},
+			givenTimeout:   time.Second,
+			expectedStatus: models.SubmissionReleased,
+			expectedPolls:  2,
+		},
+		{
+			name:           "it should fail when other terminal status is reached",
+			givenStatuses:  []string{"accepted", "failed"},
+			givenAwaited:   []models.SubmissionStatus{models.SubmissionDeliveryConfirmed},
+			givenTimeout:   time.Second,
+			expectedStatus: models.SubmissionFailed,
+			expectedPolls:  2,
+			expectedError:  "submission submission-id ended with status failed: insufficient funds",
+		},
+		{
+			name:          "it should stop on context deadline",
+			givenStatuses: []string{"accepted"},
+			givenTimeout:  20 * time.Millisecond,
+			expectedError: "context deadline exceeded",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+			client.Payment.Backoff = Backoff{Initial: time.Millisecond, Max: 4 * time.Millisecond, Multiplier: 2}
+
+			var mu sync.Mutex
+			polls := 0
+			router.HandleFunc("/v1/transaction/payments/payment-id/submissions/submission-id", func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				status := test.givenStatuses[len(test.givenStatuses)-1]
+				if polls < len(test.givenStatuses) {
+					status = test.givenStatuses[polls]
+				}
+				polls++
+				fmt.Fprintf(w, `{"data":{"id":"submission-id","attributes":{"status":%q,"status_reason":"insufficient funds"}}}`, status)
+			}).Methods(http.MethodGet)
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.givenTimeout)
+			defer cancel()
+			sub, err := client.Payment.WaitForStatus(ctx, "payment-id", "submission-id", test.givenAwaited...)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				assert.Nil(t, err)
+			}
+			if test.expectedStatus != "" {
+				require.NotNil(t, sub)
+				assert.Equal(t, test.expectedStatus, sub.Attributes.Status)
+				assert.Equal(t, test.expectedPolls, polls)
+			}
+		})
+	}
+}
+
+func TestBackoff_Next(t *testing.T) {
+	b := Backoff{Initial: time.Second, Max: 3 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, b.next(0))
+	assert.Equal(t, 2*time.Second, b.next(time.Second))
+	assert.Equal(t, 3*time.Second, b.next(2*time.Second))
+
+	b = Backoff{Initial: time.Second}
+	assert.Equal(t, 2*time.Second, b.next(time.Second), "it should use default multiplier if it is not set")
+}
+
+func TestSubmissionStatus_Terminal(t *testing.T) {
+	assert.True(t, models.SubmissionFailed.Terminal())
+	assert.True(t, models.SubmissionDeliveryConfirmed.Terminal())
+	assert.False(t, models.SubmissionReleased.Terminal())
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// SubscriptionService holds API functionality for notification subscriptions API.
+type SubscriptionService struct {
+	resources *ResourceService[models.Subscription]
+}
+
+// SubscriptionFilter is a structure required to build query parameters for filtering subscriptions list.
+type SubscriptionFilter struct {
+	RecordType string `url:"record_type,omitempty"`
+	EventType  string `url:"event_type,omitempty"`
+}
+
+// SubscriptionListOptions specifies optional parameters for listing subscriptions.
+type SubscriptionListOptions struct {
+	Pagination
+	Filter *SubscriptionFilter `url:"filter,omitempty"`
+}
+
+// Create subscribes callback URI to notifications. Callback URI, transport and record type must be specified.
+func (s *SubscriptionService) Create(ctx context.Context, subscription *models.Subscription) (*models.Subscription, *Response, error) {
+	return s.resources.Create(ctx, subscription)
+}
+
+// Fetch a single subscription using the subscription ID.
+func (s *SubscriptionService) Fetch(ctx context.Context, id string) (*models.Subscription, *Response, error) {
+	return s.resources.Fetch(ctx, id)
+}
+
+// List subscriptions with the ability to filter and page.
+func (s *SubscriptionService) List(ctx context.Context, opts *SubscriptionListOptions) ([]models.Subscription, *Response, error) {
+	return s.resources.List(ctx, opts)
+}
+
+// Delete deletes given version of a subscription.
+func (s *SubscriptionService) Delete(ctx context.Context, id string, version int) (*Response, error) {
+	return s.resources.Delete(ctx, id, version)
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionService_Create(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/notification/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data models.Subscription `json:"data"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "https://example.com/callback", req.Data.Attributes.CallbackURI)
+		assert.Equal(t, models.TransportHTTP, req.Data.Attributes.CallbackTransport)
+		assert.Equal(t, "accounts", req.Data.Attributes.RecordType)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(req)
+	}).Methods(http.MethodPost)
+
+	sub, _, err := client.Subscription.Create(context.TODO(), &models.Subscription{
+		ID:   "subscription-id",
+		Type: "subscriptions",
+		Attributes: models.SubscriptionAttributes{
+			CallbackURI:       "https://example.com/callback",
+			CallbackTransport: models.TransportHTTP,
+			RecordType:        "accounts",
+		},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "subscription-id", sub.ID)
+}
+
+func TestSubscriptionService_Fetch(t *testing.T) {
+	tests := []struct {
+		name              string
+		givenID           string
+		expectedTransport string
+		expectedError     string
+	}{
+		{
+			name:              "it should return subscription on valid response",
+			givenID:           "subscription-id",
+			expectedTransport: models.TransportQueue,
+		},
+		{
+			name:          "it should return custom api error when subscription does not exist",
+			givenID:       "missing",
+			expectedError: "code: 404, message: record missing does not exist",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, client := createTestServer()
+			defer server.Close()
+
+			router.HandleFunc("/v1/notification/subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {
+				id := mux.Vars(r)["id"]
+				if id != "subscription-id" {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprintf(w, `{"error_message":"record %s does not exist"}`, id)
+					return
+				}
+				fmt.Fprint(w, `{"data":{"id":"subscription-id","attributes":{"callback_uri":"queue-name","callback_transport":"queue","record_type":"payments"}}}`)
+			}).Methods(http.MethodGet)
+
+			sub, _, err := client.Subscription.Fetch(context.TODO(), test.givenID)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedTransport, sub.Attributes.CallbackTransport)
+		})
+	}
+}
+
+func TestSubscriptionService_List(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	router.HandleFunc("/v1/notification/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "accounts", r.URL.Query().Get("filter[record_type]"))
+		assert.Equal(t, "created", r.URL.Query().Get("filter[event_type]"))
+		fmt.Fprint(w, `{"data":[{"id":"a","attributes":{"record_type":"accounts","event_type":"created"}}]}`)
+	}).Methods(http.MethodGet)
+
+	subs, _, err := client.Subscription.List(context.TODO(), &SubscriptionListOptions{
+		Filter: &SubscriptionFilter{RecordType: "accounts", EventType: "created"},
+	})
+	require.Nil(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, "a", subs[0].ID)
+}
+
+func TestSubscriptionService_Delete(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+	var isCalled bool
+
+	router.HandleFunc("/v1/notification/subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		isCalled = true
+		assert.Equal(t, "subscription-id", mux.Vars(r)["id"])
+		assert.Equal(t, "0", r.URL.Query().Get("version"))
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodDelete)
+
+	_, err := client.Subscription.Delete(context.TODO(), "subscription-id", 0)
+	assert.Nil(t, err)
+	assert.True(t, isCalled)
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// AccountEventType describes what happened to an account between two polls.
+type AccountEventType string
+
+// Account event types emitted by AccountService.Watch.
+const (
+	AccountCreated AccountEventType = "created"
+	AccountUpdated AccountEventType = "updated"
+	AccountDeleted AccountEventType = "deleted"
+	// AccountWatchError is emitted when polling fails. Watching continues on the next tick.
+	AccountWatchError AccountEventType = "error"
+)
+
+// DefaultWatchInterval is used by Watch when given interval is not positive.
+const DefaultWatchInterval = 30 * time.Second
+
+// AccountEvent is a change of an account detected by AccountService.Watch.
+type AccountEvent struct {
+	Type AccountEventType
+	// Account is the current state of the account, or last known state of deleted account.
+	Account models.Account
+	// Err is set for AccountWatchError events.
+	Err error
+}
+
+// Watch polls accounts list every interval, following all pages, and emits events for accounts which
+// were created, updated (their version changed) or deleted since the previous poll.
+// The first poll only records the initial state and emits no events.
+// Returned channel is closed when ctx is done. DefaultWatchInterval is used if interval is not positive.
+func (s *AccountService) Watch(ctx context.Context, interval time.Duration, filter *AccountFilter) <-chan AccountEvent {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	events := make(chan AccountEvent)
+	go func() {
+		defer close(events)
+
+		var known map[string]models.Account
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			current, err := s.snapshot(ctx, filter)
+			switch {
+			case err != nil:
+				if ctx.Err() != nil {
+					return
+				}
+				if !sendAccountEvent(ctx, events, AccountEvent{Type: AccountWatchError, Err: err}) {
+					return
+				}
+			case known == nil:
+				known = current
+			default:
+				for _, event := range diffAccounts(known, current) {
+					if !sendAccountEvent(ctx, events, event) {
+						return
+					}
+				}
+				known = current
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events
+}
+
+// snapshot lists all pages of accounts matching filter and indexes them by ID.
+func (s *AccountService) snapshot(ctx context.Context, filter *AccountFilter) (map[string]models.Account, error) {
+	accounts := make(map[string]models.Account)
+	err := s.ListAll(ctx, &AccountListOptions{Filter: filter}, func(acc *models.Account) error {
+		accounts[acc.ID] = *acc
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// diffAccounts compares two snapshots. Created and updated events come first, followed by deleted ones,
+// each group ordered by account ID.
+func diffAccounts(previous, current map[string]models.Account) []AccountEvent {
+	var changed, deleted []AccountEvent
+	for id, acc := range current {
+		prev, ok := previous[id]
+		switch {
+		case !ok:
+			changed = append(changed, AccountEvent{Type: AccountCreated, Account: acc})
+		case prev.Version != acc.Version:
+			changed = append(changed, AccountEvent{Type: AccountUpdated, Account: acc})
+		}
+	}
+	for id, acc := range previous {
+		if _, ok := current[id]; !ok {
+			deleted = append(deleted, AccountEvent{Type: AccountDeleted, Account: acc})
+		}
+	}
+
+	sortAccountEvents(changed)
+	sortAccountEvents(deleted)
+	return append(changed, deleted...)
+}
+
+func sortAccountEvents(events []AccountEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Account.ID < events[j].Account.ID
+	})
+}
+
+func sendAccountEvent(ctx context.Context, events chan<- AccountEvent, event AccountEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+		return true
+	}
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAccountsState serves accounts list in pages of two and lets tests change accounts between polls.
+type fakeAccountsState struct {
+	mu       sync.Mutex
+	accounts []models.Account
+	// polls counts polls which reached the last page
+	polls    int
+	failNext bool
+	filters  []string
+}
+
+func (f *fakeAccountsState) set(accounts ...models.Account) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accounts = accounts
+}
+
+func (f *fakeAccountsState) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+	f.filters = append(f.filters, r.URL.Query().Get("filter[country]"))
+	if f.failNext {
+		f.failNext = false
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error_message":"boom"}`))
+		return
+	}
+
+	from, to := page*2, page*2+2
+	if to > len(f.accounts) {
+		to = len(f.accounts)
+	}
+	body := struct {
+		Data  []models.Account `json:"data"`
+		Links Links            `json:"links"`
+	}{Data: f.accounts[from:to]}
+	if to < len(f.accounts) {
+		next := *r.URL
+		query := next.Query()
+		query.Set("page[number]", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		body.Links.Next = next.String()
+	} else {
+		f.polls++
+	}
+	json.NewEncoder(w).Encode(body)
+}
+
+func (f *fakeAccountsState) pollCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.polls
+}
+
+func watchedAccount(id string, version int) models.Account {
+	return models.Account{ID: id, Type: "accounts", Version: version}
+}
+
+func nextAccountEvent(t *testing.T, events <-chan AccountEvent) AccountEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for account event")
+	}
+	return AccountEvent{}
+}
+
+func waitForPoll(t *testing.T, state *fakeAccountsState, poll int) {
+	deadline := time.Now().Add(time.Second)
+	for state.pollCount() < poll {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for poll %d", poll)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAccountService_Watch(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	state := &fakeAccountsState{}
+	state.set(watchedAccount("a", 0), watchedAccount("b", 0), watchedAccount("c", 0))
+	router.Handle("/v1/organisation/accounts", state).Methods(http.MethodGet)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := client.Account.Watch(ctx, 10*time.Millisecond, &AccountFilter{Country: "GB"})
+
+	waitForPoll(t, state, 1)
+	state.set(watchedAccount("a", 1), watchedAccount("c", 0), watchedAccount("d", 0))
+
+	expected := []AccountEvent{
+		{Type: AccountUpdated, Account: watchedAccount("a", 1)},
+		{Type: AccountCreated, Account: watchedAccount("d", 0)},
+		{Type: AccountDeleted, Account: watchedAccount("b", 0)},
+	}
+	for _, want := range expected {
+		assert.Equal(t, want, nextAccountEvent(t, events))
+	}
+
+	cancel()
+	for range events {
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	for _, filter := range state.filters {
+		assert.Equal(t, "GB", filter)
+	}
+}
+
+func TestAccountService_WatchError(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	state := &fakeAccountsState{}
+	state.set(watchedAccount("a", 0))
+	router.Handle("/v1/organisation/accounts", state).Methods(http.MethodGet)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := client.Account.Watch(ctx, 10*time.Millisecond, nil)
+
+	waitForPoll(t, state, 1)
+	state.mu.Lock()
+	state.failNext = true
+	state.mu.Unlock()
+
+	event := nextAccountEvent(t, events)
+	assert.Equal(t, AccountWatchError, event.Type)
+	require.EqualError(t, event.Err, "code: 500, message: boom")
+
+	state.set()
+	assert.Equal(t, AccountEvent{Type: AccountDeleted, Account: watchedAccount("a", 0)}, nextAccountEvent(t, events))
+
+	cancel()
+	_, open := <-events
+	for open {
+		_, open = <-events
+	}
+}
+
+func TestDiffAccounts(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenPrevious  map[string]models.Account
+		givenCurrent   map[string]models.Account
+		expectedEvents []AccountEvent
+	}{
+		{
+			name:          "it should emit no events when nothing changed",
+			givenPrevious: map[string]models.Account{"a": watchedAccount("a", 0)},
+			givenCurrent:  map[string]models.Account{"a": watchedAccount("a", 0)},
+		},
+		{
+			name:          "it should order events by type and account id",
+			givenPrevious: map[string]models.Account{"a": watchedAccount("a", 0), "z": watchedAccount("z", 0)},
+			givenCurrent:  map[string]models.Account{"c": watchedAccount("c", 0), "b": watchedAccount("b", 0)},
+			expectedEvents: []AccountEvent{
+				{Type: AccountCreated, Account: watchedAccount("b", 0)},
+				{Type: AccountCreated, Account: watchedAccount("c", 0)},
+				{Type: AccountDeleted, Account: watchedAccount("a", 0)},
+				{Type: AccountDeleted, Account: watchedAccount("z", 0)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedEvents, diffAccounts(test.givenPrevious, test.givenCurrent))
+		})
+	}
+}
+
+func TestAccountService_WatchNonPositiveInterval(t *testing.T) {
+	router, server, client := createTestServer()
+	defer server.Close()
+
+	state := &fakeAccountsState{}
+	router.Handle("/v1/organisation/accounts", state).Methods(http.MethodGet)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := client.Account.Watch(ctx, 0, nil)
+	waitForPoll(t, state, 1)
+	cancel()
+	for range events {
+	}
+	assert.Equal(t, 1, state.pollCount(), "it should fall back to default interval instead of panicking")
+}
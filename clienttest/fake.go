@@ -0,0 +1,436 @@
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// defaultPageSize is page size used by Fake when none is given, same as the account API.
+const defaultPageSize = 100
+
+// Fake is an in-memory client.AccountAPI. It validates versions and reports errors the same way
+// as the account API, returning *client.ErrorResponse. Accounts are listed ordered by ID.
+type Fake struct {
+	mu       sync.Mutex
+	accounts map[string]models.Account
+	watchers []*fakeWatcher
+}
+
+var _ client.AccountAPI = (*Fake)(nil)
+
+type fakeWatcher struct {
+	ctx    context.Context
+	filter *client.AccountFilter
+	events chan client.AccountEvent
+}
+
+// NewFake creates Fake holding given accounts.
+func NewFake(accounts ...models.Account) *Fake {
+	f := &Fake{accounts: make(map[string]models.Account)}
+	for _, acc := range accounts {
+		f.accounts[acc.ID] = acc
+	}
+	return f
+}
+
+// Accounts returns all stored accounts ordered by ID.
+func (f *Fake) Accounts() []models.Account {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.matching(nil)
+}
+
+// Create implements client.AccountAPI.
+func (f *Fake) Create(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if account.ID == "" {
+		return nil, nil, fakeError(http.StatusBadRequest, "id in body is required")
+	}
+	if _, ok := f.accounts[account.ID]; ok {
+		return nil, nil, fakeError(http.StatusConflict, "Account cannot be created as it violates a duplicate constraint")
+	}
+
+	created := *account
+	created.Version = 0
+	f.accounts[created.ID] = created
+	f.notify(client.AccountEvent{Type: client.AccountCreated, Account: created})
+	return &created, &client.Response{}, nil
+}
+
+// Fetch implements client.AccountAPI.
+func (f *Fake) Fetch(ctx context.Context, id string) (*models.Account, *client.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	acc, ok := f.accounts[id]
+	if !ok {
+		return nil, nil, fakeError(http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+	}
+	return &acc, &client.Response{}, nil
+}
+
+// FetchWithOptions implements client.AccountAPI. Only master account can be included, as Fake holds
+// no other resources.
+func (f *Fake) FetchWithOptions(ctx context.Context, id string, opts *client.AccountFetchOptions) (*models.Account, *client.Response, error) {
+	acc, resp, err := f.Fetch(ctx, id)
+	if err != nil || opts == nil || acc.Relationships == nil || acc.Relationships.MasterAccount == nil {
+		return acc, resp, err
+	}
+
+	for _, include := range opts.Include {
+		if include != client.IncludeMasterAccount || acc.Relationships.MasterAccount.Data == nil {
+			continue
+		}
+		f.mu.Lock()
+		master, ok := f.accounts[acc.Relationships.MasterAccount.Data.ID]
+		f.mu.Unlock()
+		if !ok {
+			continue
+		}
+		raw, err := json.Marshal(master)
+		if err != nil {
+			return nil, nil, err
+		}
+		resp.Included = append(resp.Included, raw)
+	}
+	return acc, resp, nil
+}
+
+// List implements client.AccountAPI. Pages are numbered from 0.
+func (f *Fake) List(ctx context.Context, pagination *client.Pagination) ([]models.Account, *client.Response, error) {
+	return f.ListWithOptions(ctx, listOptions(pagination))
+}
+
+// ListWithOptions implements client.AccountAPI. Pages are numbered from 0.
+func (f *Fake) ListWithOptions(ctx context.Context, opts *client.AccountListOptions) ([]models.Account, *client.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var filter *client.AccountFilter
+	page, size := 0, defaultPageSize
+	if opts != nil {
+		filter = opts.Filter
+		page = opts.Page
+		if opts.PerPage > 0 {
+			size = opts.PerPage
+		}
+	}
+
+	accounts := f.matching(filter)
+	start := page * size
+	if start > len(accounts) {
+		start = len(accounts)
+	}
+	end := start + size
+	if end > len(accounts) {
+		end = len(accounts)
+	}
+
+	resp := &client.Response{}
+	if end < len(accounts) {
+		resp.Links.Next = fmt.Sprintf("/v1/organisation/accounts?page[number]=%d&page[size]=%d", page+1, size)
+	}
+	return accounts[start:end], resp, nil
+}
+
+// ListEach implements client.AccountAPI.
+func (f *Fake) ListEach(ctx context.Context, pagination *client.Pagination, fn func(*models.Account) error) (*client.Response, error) {
+	return f.ListEachWithOptions(ctx, listOptions(pagination), fn)
+}
+
+// ListEachWithOptions implements client.AccountAPI.
+func (f *Fake) ListEachWithOptions(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) (*client.Response, error) {
+	accounts, resp, err := f.ListWithOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range accounts {
+		if err := fn(&accounts[i]); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// ListAll implements client.AccountAPI. It pages through accounts like the client does, starting at opts.Page.
+func (f *Fake) ListAll(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) error {
+	page := &client.AccountListOptions{}
+	if opts != nil {
+		*page = *opts
+	}
+
+	for {
+		accounts, resp, err := f.ListWithOptions(ctx, page)
+		if err != nil {
+			return err
+		}
+		for i := range accounts {
+			if err := fn(&accounts[i]); err != nil {
+				return err
+			}
+		}
+		if resp.Links.IsLastPage() {
+			return nil
+		}
+		page.Page++
+	}
+}
+
+// ListPartial implements client.AccountAPI. Attributes not given in opts.Fields are left out,
+// but accounts are ordered by ID regardless of opts.Sort.
+func (f *Fake) ListPartial(ctx context.Context, opts *client.AccountListOptions) ([]models.PartialAccount, *client.Response, error) {
+	accounts, resp, err := f.ListWithOptions(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	partials := make([]models.PartialAccount, len(accounts))
+	for i := range accounts {
+		if err := partial(&accounts[i], opts, &partials[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return partials, resp, nil
+}
+
+// ListAllPartial implements client.AccountAPI like ListPartial does.
+func (f *Fake) ListAllPartial(ctx context.Context, opts *client.AccountListOptions, fn func(*models.PartialAccount) error) error {
+	return f.ListAll(ctx, opts, func(acc *models.Account) error {
+		var p models.PartialAccount
+		if err := partial(acc, opts, &p); err != nil {
+			return err
+		}
+		return fn(&p)
+	})
+}
+
+// Update implements client.AccountAPI. Version of the account must match the stored one.
+func (f *Fake) Update(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.accounts[account.ID]
+	if !ok {
+		return nil, nil, fakeError(http.StatusNotFound, fmt.Sprintf("record %s does not exist", account.ID))
+	}
+	if stored.Version != account.Version {
+		return nil, nil, fakeError(http.StatusConflict, "invalid version")
+	}
+
+	updated := *account
+	updated.Version++
+	f.accounts[updated.ID] = updated
+	f.notify(client.AccountEvent{Type: client.AccountUpdated, Account: updated})
+	return &updated, &client.Response{}, nil
+}
+
+// UpdateClearing updates account like client.AccountService.UpdateClearing does, removing given attributes.
+func (f *Fake) UpdateClearing(ctx context.Context, account *models.Account, attributes []string) (*models.Account, *client.Response, error) {
+	data, err := json.Marshal(account.Attributes)
+	if err != nil {
+		return nil, nil, err
+	}
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, nil, err
+	}
+	for _, name := range attributes {
+		delete(attrs, name)
+	}
+	if data, err = json.Marshal(attrs); err != nil {
+		return nil, nil, err
+	}
+
+	cleared := *account
+	cleared.Attributes = models.AccountAttributes{}
+	if err := json.Unmarshal(data, &cleared.Attributes); err != nil {
+		return nil, nil, err
+	}
+	return f.Update(ctx, &cleared)
+}
+
+// Delete implements client.AccountAPI.
+func (f *Fake) Delete(ctx context.Context, id string) (*client.Response, error) {
+	return f.DeleteVersion(ctx, id, 0)
+}
+
+// DeleteVersion implements client.AccountAPI.
+func (f *Fake) DeleteVersion(ctx context.Context, id string, version int) (*client.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.accounts[id]
+	if !ok {
+		return nil, fakeError(http.StatusNotFound, "")
+	}
+	if stored.Version != version {
+		return nil, fakeError(http.StatusConflict, "invalid version")
+	}
+
+	delete(f.accounts, id)
+	f.notify(client.AccountEvent{Type: client.AccountDeleted, Account: stored})
+	return &client.Response{}, nil
+}
+
+// Close implements client.AccountAPI. Transition is validated like client.AccountService does.
+func (f *Fake) Close(ctx context.Context, account *models.Account, reason string) (*models.Account, *client.Response, error) {
+	return f.changeStatus(ctx, account, models.AccountClosed, func(attrs *models.AccountAttributes) {
+		attrs.StatusReason = reason
+	})
+}
+
+// Reopen implements client.AccountAPI.
+func (f *Fake) Reopen(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error) {
+	return f.changeStatus(ctx, account, models.AccountConfirmed, func(attrs *models.AccountAttributes) {
+		attrs.StatusReason = ""
+	})
+}
+
+// Switch implements client.AccountAPI.
+func (f *Fake) Switch(ctx context.Context, account *models.Account, switched models.SwitchedAccount) (*models.Account, *client.Response, error) {
+	return f.changeStatus(ctx, account, models.AccountSwitched, func(attrs *models.AccountAttributes) {
+		attrs.StatusReason = ""
+		attrs.SwitchedAccount = &switched
+	})
+}
+
+// changeStatus validates transition of account to given status and updates it with the status and
+// attributes set by fn.
+func (f *Fake) changeStatus(ctx context.Context, account *models.Account, to models.AccountStatus, fn func(*models.AccountAttributes)) (*models.Account, *client.Response, error) {
+	from := account.Attributes.Status.Effective()
+	if !from.CanTransition(to) {
+		return nil, nil, &client.InvalidTransitionError{Resource: "account", ID: account.ID, From: string(from), To: string(to)}
+	}
+
+	changed := *account
+	changed.Attributes.Status = to
+	fn(&changed.Attributes)
+	return f.Update(ctx, &changed)
+}
+
+// Watch implements client.AccountAPI. Events are emitted as soon as accounts change, interval is ignored.
+// Changes made while an event is not received yet block the Fake, so the channel should be drained.
+func (f *Fake) Watch(ctx context.Context, interval time.Duration, filter *client.AccountFilter) <-chan client.AccountEvent {
+	w := &fakeWatcher{ctx: ctx, filter: filter, events: make(chan client.AccountEvent)}
+
+	f.mu.Lock()
+	f.watchers = append(f.watchers, w)
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i, watcher := range f.watchers {
+			if watcher == w {
+				f.watchers = append(f.watchers[:i], f.watchers[i+1:]...)
+				break
+			}
+		}
+		close(w.events)
+	}()
+
+	return w.events
+}
+
+// notify sends event to watchers with matching filter. Must be called with f.mu held.
+func (f *Fake) notify(event client.AccountEvent) {
+	for _, w := range f.watchers {
+		if !matchesFilter(&event.Account, w.filter) {
+			continue
+		}
+		select {
+		case w.events <- event:
+		case <-w.ctx.Done():
+		}
+	}
+}
+
+// matching returns accounts matching filter ordered by ID. Must be called with f.mu held.
+func (f *Fake) matching(filter *client.AccountFilter) []models.Account {
+	accounts := make([]models.Account, 0, len(f.accounts))
+	for _, acc := range f.accounts {
+		if matchesFilter(&acc, filter) {
+			accounts = append(accounts, acc)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].ID < accounts[j].ID
+	})
+	return accounts
+}
+
+// matchesFilter reports whether account matches all fields set on filter.
+// Accounts have no customer ID, so filtering by it matches nothing.
+func matchesFilter(acc *models.Account, filter *client.AccountFilter) bool {
+	if filter == nil {
+		return true
+	}
+	attrs := acc.Attributes
+	return matchesValue(attrs.BankIDCode, filter.BankIDCode) &&
+		matchesValue(attrs.BankID, filter.BankID) &&
+		matchesValue(attrs.AccountNumber, filter.AccountNumber) &&
+		matchesValue(attrs.Iban, filter.Iban) &&
+		matchesValue(attrs.Country, filter.Country) &&
+		matchesValue(acc.OrganisationID, filter.OrganisationID) &&
+		filter.CustomerID == ""
+}
+
+func matchesValue(value, filter string) bool {
+	return filter == "" || value == filter
+}
+
+// partial decodes account into p as the API returns it for sparse fieldset given in opts.
+func partial(acc *models.Account, opts *client.AccountListOptions, p *models.PartialAccount) error {
+	data, err := json.Marshal(acc)
+	if err != nil {
+		return err
+	}
+	if opts == nil || len(opts.Fields) == 0 {
+		return json.Unmarshal(data, p)
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(members["attributes"], &attributes); err != nil {
+		return err
+	}
+	selected := make(map[string]json.RawMessage, len(opts.Fields))
+	for _, name := range opts.Fields {
+		if value, ok := attributes[name]; ok {
+			selected[name] = value
+		}
+	}
+	if members["attributes"], err = json.Marshal(selected); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(members); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, p)
+}
+
+func fakeError(status int, message string) *client.ErrorResponse {
+	return &client.ErrorResponse{StatusCode: status, Message: message}
+}
+
+// listOptions returns list options with given pagination, or nil if it is nil.
+func listOptions(pagination *client.Pagination) *client.AccountListOptions {
+	if pagination == nil {
+		return nil
+	}
+	return &client.AccountListOptions{Pagination: *pagination}
+}
@@ -0,0 +1,249 @@
+package clienttest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake_Lifecycle(t *testing.T) {
+	ctx := context.TODO()
+	f := NewFake()
+
+	created, _, err := f.Create(ctx, &models.Account{ID: "a", Attributes: models.AccountAttributes{Country: "GB"}})
+	require.Nil(t, err)
+	assert.Equal(t, 0, created.Version)
+
+	_, _, err = f.Create(ctx, &models.Account{ID: "a"})
+	assertStatus(t, http.StatusConflict, err)
+
+	created.Attributes.Bic = "NWBKGB22"
+	updated, _, err := f.Update(ctx, created)
+	require.Nil(t, err)
+	assert.Equal(t, 1, updated.Version)
+
+	_, _, err = f.Update(ctx, created)
+	assertStatus(t, http.StatusConflict, err)
+
+	fetched, _, err := f.Fetch(ctx, "a")
+	require.Nil(t, err)
+	assert.Equal(t, "NWBKGB22", fetched.Attributes.Bic)
+
+	_, err = f.Delete(ctx, "a")
+	assertStatus(t, http.StatusConflict, err)
+	_, err = f.DeleteVersion(ctx, "a", 1)
+	require.Nil(t, err)
+
+	_, _, err = f.Fetch(ctx, "a")
+	assertStatus(t, http.StatusNotFound, err)
+	assert.EqualError(t, err, "code: 404, message: record a does not exist")
+}
+
+func TestFake_UpdateClearing(t *testing.T) {
+	f := NewFake(models.Account{ID: "a", Attributes: models.AccountAttributes{Country: "GB", Bic: "NWBKGB22", Iban: "GB33BUKB20201555555555"}})
+	acc, _, err := f.Fetch(context.TODO(), "a")
+	require.Nil(t, err)
+
+	updated, _, err := f.UpdateClearing(context.TODO(), acc, []string{"bic"})
+	require.Nil(t, err)
+	assert.Equal(t, models.AccountAttributes{Country: "GB", Iban: "GB33BUKB20201555555555"}, updated.Attributes)
+	assert.Equal(t, "NWBKGB22", acc.Attributes.Bic, "it should not modify given account")
+}
+
+func TestFake_FetchWithOptions(t *testing.T) {
+	virtual := models.Account{ID: "virtual", Relationships: &models.AccountRelationships{
+		MasterAccount: models.NewRelationship("accounts", "master"),
+	}}
+	f := NewFake(virtual, models.Account{ID: "master", Type: "accounts", Attributes: models.AccountAttributes{Country: "GB"}})
+
+	acc, resp, err := f.FetchWithOptions(context.TODO(), "virtual", &client.AccountFetchOptions{Include: []string{client.IncludeMasterAccount}})
+	require.Nil(t, err)
+	master, err := client.Resolve[models.Account](resp, acc.Relationships.MasterAccount)
+	require.Nil(t, err)
+	require.NotNil(t, master)
+	assert.Equal(t, "GB", master.Attributes.Country)
+
+	_, resp, err = f.FetchWithOptions(context.TODO(), "virtual", nil)
+	require.Nil(t, err)
+	assert.Empty(t, resp.Included, "it should include master account only when asked")
+}
+
+func TestFake_ChangeStatus(t *testing.T) {
+	ctx := context.TODO()
+	f := NewFake(models.Account{ID: "a", Attributes: models.AccountAttributes{Country: "GB", Status: models.AccountConfirmed}})
+
+	acc, _, err := f.Fetch(ctx, "a")
+	require.Nil(t, err)
+	_, _, err = f.Reopen(ctx, acc)
+	assert.EqualError(t, err, `account a cannot change status from "confirmed" to "confirmed"`)
+
+	closed, _, err := f.Close(ctx, acc, "customer request")
+	require.Nil(t, err)
+	assert.Equal(t, models.AccountClosed, closed.Attributes.Status)
+	assert.Equal(t, "customer request", closed.Attributes.StatusReason)
+	assert.Equal(t, 1, closed.Version)
+
+	_, _, err = f.Switch(ctx, closed, models.SwitchedAccount{AccountNumber: "10000004"})
+	_, ok := err.(*client.InvalidTransitionError)
+	assert.True(t, ok)
+
+	reopened, _, err := f.Reopen(ctx, closed)
+	require.Nil(t, err)
+	assert.Equal(t, models.AccountConfirmed, reopened.Attributes.Status)
+	assert.Empty(t, reopened.Attributes.StatusReason)
+}
+
+func TestFake_List(t *testing.T) {
+	f := NewFake(
+		models.Account{ID: "c", Attributes: models.AccountAttributes{Country: "GB"}},
+		models.Account{ID: "a", Attributes: models.AccountAttributes{Country: "GB"}},
+		models.Account{ID: "b", Attributes: models.AccountAttributes{Country: "FR"}},
+	)
+	tests := []struct {
+		name         string
+		givenOpts    *client.AccountListOptions
+		expectedIDs  []string
+		expectedNext bool
+	}{
+		{
+			name:        "it should list all accounts ordered by id",
+			givenOpts:   nil,
+			expectedIDs: []string{"a", "b", "c"},
+		},
+		{
+			name:         "it should list first page",
+			givenOpts:    &client.AccountListOptions{Pagination: client.Pagination{PerPage: 2}},
+			expectedIDs:  []string{"a", "b"},
+			expectedNext: true,
+		},
+		{
+			name:        "it should list last page",
+			givenOpts:   &client.AccountListOptions{Pagination: client.Pagination{Page: 1, PerPage: 2}},
+			expectedIDs: []string{"c"},
+		},
+		{
+			name:        "it should filter accounts",
+			givenOpts:   &client.AccountListOptions{Filter: &client.AccountFilter{Country: "GB"}},
+			expectedIDs: []string{"a", "c"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			accounts, resp, err := f.ListWithOptions(context.TODO(), test.givenOpts)
+			require.Nil(t, err)
+
+			ids := []string{}
+			for _, acc := range accounts {
+				ids = append(ids, acc.ID)
+			}
+			assert.Equal(t, test.expectedIDs, ids)
+			assert.Equal(t, test.expectedNext, resp.Links.Next != "")
+		})
+	}
+}
+
+func TestFake_ListAll(t *testing.T) {
+	f := NewFake(
+		models.Account{ID: "a", Attributes: models.AccountAttributes{Country: "GB"}},
+		models.Account{ID: "b", Attributes: models.AccountAttributes{Country: "FR"}},
+		models.Account{ID: "c", Attributes: models.AccountAttributes{Country: "GB"}},
+		models.Account{ID: "d", Attributes: models.AccountAttributes{Country: "GB"}},
+	)
+	tests := []struct {
+		name        string
+		givenOpts   *client.AccountListOptions
+		expectedIDs []string
+	}{
+		{
+			name:        "it should list all accounts",
+			givenOpts:   nil,
+			expectedIDs: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:        "it should list all pages of given size",
+			givenOpts:   &client.AccountListOptions{Pagination: client.Pagination{PerPage: 1}},
+			expectedIDs: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:        "it should start at given page",
+			givenOpts:   &client.AccountListOptions{Pagination: client.Pagination{Page: 1, PerPage: 3}},
+			expectedIDs: []string{"d"},
+		},
+		{
+			name:        "it should filter accounts on every page",
+			givenOpts:   &client.AccountListOptions{Pagination: client.Pagination{Page: 1, PerPage: 1}, Filter: &client.AccountFilter{Country: "GB"}},
+			expectedIDs: []string{"c", "d"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ids := []string{}
+			err := f.ListAll(context.TODO(), test.givenOpts, func(acc *models.Account) error {
+				ids = append(ids, acc.ID)
+				return nil
+			})
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedIDs, ids)
+		})
+	}
+}
+
+func TestFake_ListPartial(t *testing.T) {
+	f := NewFake(
+		models.Account{ID: "a", Attributes: models.AccountAttributes{Country: "GB", BankID: "400302", Bic: "NWBKGB22"}},
+		models.Account{ID: "b", Attributes: models.AccountAttributes{Country: "FR", BankID: "20041"}},
+	)
+	opts := &client.AccountListOptions{Filter: &client.AccountFilter{Country: "GB"}, Fields: []string{"bank_id"}}
+
+	accounts, _, err := f.ListPartial(context.TODO(), opts)
+	require.Nil(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, "400302", accounts[0].Attributes.BankID)
+	assert.Empty(t, accounts[0].Attributes.Bic, "it should leave out attributes which were not selected")
+	assert.True(t, accounts[0].Fields.Has("bank_id"))
+	assert.False(t, accounts[0].Fields.Has("bic"))
+
+	var ids []string
+	err = f.ListAllPartial(context.TODO(), nil, func(acc *models.PartialAccount) error {
+		ids = append(ids, acc.ID)
+		assert.True(t, acc.Fields.Has("country"))
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, ids)
+}
+
+func TestFake_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := NewFake()
+	events := f.Watch(ctx, time.Second, &client.AccountFilter{Country: "GB"})
+
+	go func() {
+		f.Create(context.TODO(), &models.Account{ID: "fr", Attributes: models.AccountAttributes{Country: "FR"}})
+		f.Create(context.TODO(), &models.Account{ID: "gb", Attributes: models.AccountAttributes{Country: "GB"}})
+		f.DeleteVersion(context.TODO(), "gb", 0)
+		cancel()
+	}()
+
+	var got []client.AccountEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, client.AccountCreated, got[0].Type)
+	assert.Equal(t, "gb", got[0].Account.ID)
+	assert.Equal(t, client.AccountDeleted, got[1].Type)
+}
+
+func assertStatus(t *testing.T, expected int, err error) {
+	t.Helper()
+	errResp, ok := err.(*client.ErrorResponse)
+	require.True(t, ok, "expected *client.ErrorResponse, got %v", err)
+	assert.Equal(t, expected, errResp.StatusCode)
+}
@@ -0,0 +1,329 @@
+// Package clienttest provides test doubles of client.AccountAPI: an expectation based Mock and
+// an in-memory Fake behaving like the account API.
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// ErrUnexpectedCall is returned by Mock for calls which were not expected.
+var ErrUnexpectedCall = errors.New("clienttest: unexpected call")
+
+// TestingT is the subset of testing.T used by Mock.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Helper()
+}
+
+// Any matches any argument value.
+var Any = anyArg{}
+
+type anyArg struct{}
+
+// Expectation is an expected call of a Mock method.
+type Expectation struct {
+	method  string
+	args    []interface{}
+	returns []interface{}
+	times   int
+	calls   int
+}
+
+// Return sets values returned by the call, in order of method results. Missing values are returned as zero values.
+func (e *Expectation) Return(values ...interface{}) *Expectation {
+	e.returns = values
+	return e
+}
+
+// Times sets how many times the call is expected. Defaults to 1.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+func (e *Expectation) String() string {
+	return fmt.Sprintf("%s(%v)", e.method, e.args)
+}
+
+func (e *Expectation) matches(method string, args []interface{}) bool {
+	if e.method != method || len(e.args) != len(args) || e.calls >= e.times {
+		return false
+	}
+	for i, want := range e.args {
+		if _, ok := want.(anyArg); ok {
+			continue
+		}
+		if !reflect.DeepEqual(want, args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Mock is client.AccountAPI returning preset values for expected calls. Arguments are matched
+// by value, except context which is never matched. Unexpected calls fail the test.
+//
+//	m := clienttest.NewMock(t)
+//	m.Expect("Fetch", "account-id").Return(&models.Account{ID: "account-id"}, nil, nil)
+//	defer m.AssertExpectations()
+type Mock struct {
+	t TestingT
+
+	mu           sync.Mutex
+	expectations []*Expectation
+}
+
+var _ client.AccountAPI = (*Mock)(nil)
+
+// NewMock creates Mock reporting failures to t.
+func NewMock(t TestingT) *Mock {
+	return &Mock{t: t}
+}
+
+// Expect registers expected call of method with given arguments, without the context.
+func (m *Mock) Expect(method string, args ...interface{}) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &Expectation{method: method, args: args, times: 1}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// AssertExpectations fails the test if some expected calls were not made.
+func (m *Mock) AssertExpectations() {
+	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.expectations {
+		if e.calls < e.times {
+			m.t.Errorf("clienttest: expected call %s %d times, but got %d", e, e.times, e.calls)
+		}
+	}
+}
+
+// called finds matching expectation and returns its values padded to n results.
+func (m *Mock) called(method string, n int, args ...interface{}) ([]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.expectations {
+		if e.matches(method, args) {
+			e.calls++
+			returns := make([]interface{}, n)
+			copy(returns, e.returns)
+			return returns, nil
+		}
+	}
+
+	m.t.Helper()
+	m.t.Errorf("clienttest: unexpected call %s(%v)", method, args)
+	return make([]interface{}, n), ErrUnexpectedCall
+}
+
+// Create implements client.AccountAPI.
+func (m *Mock) Create(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error) {
+	r, err := m.called("Create", 3, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountValue(r[0]), responseValue(r[1]), errorValue(r[2])
+}
+
+// Fetch implements client.AccountAPI.
+func (m *Mock) Fetch(ctx context.Context, id string) (*models.Account, *client.Response, error) {
+	r, err := m.called("Fetch", 3, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountValue(r[0]), responseValue(r[1]), errorValue(r[2])
+}
+
+// FetchWithOptions implements client.AccountAPI.
+func (m *Mock) FetchWithOptions(ctx context.Context, id string, opts *client.AccountFetchOptions) (*models.Account, *client.Response, error) {
+	r, err := m.called("FetchWithOptions", 3, id, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountValue(r[0]), responseValue(r[1]), errorValue(r[2])
+}
+
+// List implements client.AccountAPI.
+func (m *Mock) List(ctx context.Context, pagination *client.Pagination) ([]models.Account, *client.Response, error) {
+	return m.list("List", pagination)
+}
+
+// ListWithOptions implements client.AccountAPI.
+func (m *Mock) ListWithOptions(ctx context.Context, opts *client.AccountListOptions) ([]models.Account, *client.Response, error) {
+	return m.list("ListWithOptions", opts)
+}
+
+func (m *Mock) list(method string, arg interface{}) ([]models.Account, *client.Response, error) {
+	r, err := m.called(method, 3, arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	accounts, _ := r[0].([]models.Account)
+	return accounts, responseValue(r[1]), errorValue(r[2])
+}
+
+// ListEach implements client.AccountAPI. Accounts set as the first return value are passed to fn.
+func (m *Mock) ListEach(ctx context.Context, pagination *client.Pagination, fn func(*models.Account) error) (*client.Response, error) {
+	return m.listEach("ListEach", pagination, fn)
+}
+
+// ListEachWithOptions implements client.AccountAPI. Accounts set as the first return value are passed to fn.
+func (m *Mock) ListEachWithOptions(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) (*client.Response, error) {
+	return m.listEach("ListEachWithOptions", opts, fn)
+}
+
+func (m *Mock) listEach(method string, arg interface{}, fn func(*models.Account) error) (*client.Response, error) {
+	r, err := m.called(method, 3, arg)
+	if err != nil {
+		return nil, err
+	}
+	if err := eachAccount(r[0], fn); err != nil {
+		return responseValue(r[1]), err
+	}
+	return responseValue(r[1]), errorValue(r[2])
+}
+
+// ListAll implements client.AccountAPI. Accounts set as the first return value are passed to fn.
+func (m *Mock) ListAll(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) error {
+	r, err := m.called("ListAll", 2, opts)
+	if err != nil {
+		return err
+	}
+	if err := eachAccount(r[0], fn); err != nil {
+		return err
+	}
+	return errorValue(r[1])
+}
+
+// ListPartial implements client.AccountAPI.
+func (m *Mock) ListPartial(ctx context.Context, opts *client.AccountListOptions) ([]models.PartialAccount, *client.Response, error) {
+	r, err := m.called("ListPartial", 3, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	accounts, _ := r[0].([]models.PartialAccount)
+	return accounts, responseValue(r[1]), errorValue(r[2])
+}
+
+// ListAllPartial implements client.AccountAPI. Accounts set as the first return value are passed to fn.
+func (m *Mock) ListAllPartial(ctx context.Context, opts *client.AccountListOptions, fn func(*models.PartialAccount) error) error {
+	r, err := m.called("ListAllPartial", 2, opts)
+	if err != nil {
+		return err
+	}
+	accounts, _ := r[0].([]models.PartialAccount)
+	for i := range accounts {
+		if err := fn(&accounts[i]); err != nil {
+			return err
+		}
+	}
+	return errorValue(r[1])
+}
+
+// Update implements client.AccountAPI.
+func (m *Mock) Update(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error) {
+	r, err := m.called("Update", 3, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountValue(r[0]), responseValue(r[1]), errorValue(r[2])
+}
+
+// Delete implements client.AccountAPI.
+func (m *Mock) Delete(ctx context.Context, id string) (*client.Response, error) {
+	r, err := m.called("Delete", 2, id)
+	if err != nil {
+		return nil, err
+	}
+	return responseValue(r[0]), errorValue(r[1])
+}
+
+// DeleteVersion implements client.AccountAPI.
+func (m *Mock) DeleteVersion(ctx context.Context, id string, version int) (*client.Response, error) {
+	r, err := m.called("DeleteVersion", 2, id, version)
+	if err != nil {
+		return nil, err
+	}
+	return responseValue(r[0]), errorValue(r[1])
+}
+
+// Close implements client.AccountAPI.
+func (m *Mock) Close(ctx context.Context, account *models.Account, reason string) (*models.Account, *client.Response, error) {
+	r, err := m.called("Close", 3, account, reason)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountValue(r[0]), responseValue(r[1]), errorValue(r[2])
+}
+
+// Reopen implements client.AccountAPI.
+func (m *Mock) Reopen(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error) {
+	r, err := m.called("Reopen", 3, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountValue(r[0]), responseValue(r[1]), errorValue(r[2])
+}
+
+// Switch implements client.AccountAPI.
+func (m *Mock) Switch(ctx context.Context, account *models.Account, switched models.SwitchedAccount) (*models.Account, *client.Response, error) {
+	r, err := m.called("Switch", 3, account, switched)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountValue(r[0]), responseValue(r[1]), errorValue(r[2])
+}
+
+// Watch implements client.AccountAPI. The returned channel must be set as return value.
+func (m *Mock) Watch(ctx context.Context, interval time.Duration, filter *client.AccountFilter) <-chan client.AccountEvent {
+	r, err := m.called("Watch", 1, interval, filter)
+	if err != nil {
+		return nil
+	}
+	switch events := r[0].(type) {
+	case chan client.AccountEvent:
+		return events
+	case <-chan client.AccountEvent:
+		return events
+	}
+	return nil
+}
+
+func eachAccount(v interface{}, fn func(*models.Account) error) error {
+	accounts, _ := v.([]models.Account)
+	for i := range accounts {
+		if err := fn(&accounts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func accountValue(v interface{}) *models.Account {
+	acc, _ := v.(*models.Account)
+	return acc
+}
+
+func responseValue(v interface{}) *client.Response {
+	resp, _ := v.(*client.Response)
+	return resp
+}
+
+func errorValue(v interface{}) error {
+	err, _ := v.(error)
+	return err
+}
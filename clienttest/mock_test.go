@@ -0,0 +1,137 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT records failures instead of failing the test.
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Helper() {}
+
+func TestMock_Fetch(t *testing.T) {
+	rt := &recordingT{}
+	m := NewMock(rt)
+	m.Expect("Fetch", "account-id").Return(&models.Account{ID: "account-id"}, &client.Response{}, nil)
+
+	acc, resp, err := m.Fetch(context.TODO(), "account-id")
+	require.Nil(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, "account-id", acc.ID)
+
+	m.AssertExpectations()
+	assert.Empty(t, rt.errors)
+}
+
+func TestMock_Calls(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name           string
+		givenExpect    func(m *Mock)
+		givenCall      func(m *Mock) error
+		expectedErr    error
+		expectedErrors int
+	}{
+		{
+			name: "it should return error set on expectation",
+			givenExpect: func(m *Mock) {
+				m.Expect("DeleteVersion", "account-id", 2).Return(nil, errFailed)
+			},
+			givenCall: func(m *Mock) error {
+				_, err := m.DeleteVersion(context.TODO(), "account-id", 2)
+				return err
+			},
+			expectedErr: errFailed,
+		},
+		{
+			name: "it should match any argument",
+			givenExpect: func(m *Mock) {
+				m.Expect("Update", Any)
+			},
+			givenCall: func(m *Mock) error {
+				_, _, err := m.Update(context.TODO(), &models.Account{ID: "account-id"})
+				return err
+			},
+		},
+		{
+			name: "it should fail on unexpected arguments",
+			givenExpect: func(m *Mock) {
+				m.Expect("Fetch", "account-id")
+			},
+			givenCall: func(m *Mock) error {
+				_, _, err := m.Fetch(context.TODO(), "other-id")
+				return err
+			},
+			expectedErr:    ErrUnexpectedCall,
+			expectedErrors: 2,
+		},
+		{
+			name: "it should fail when called more times than expected",
+			givenExpect: func(m *Mock) {
+				m.Expect("Delete", "account-id")
+			},
+			givenCall: func(m *Mock) error {
+				m.Delete(context.TODO(), "account-id")
+				_, err := m.Delete(context.TODO(), "account-id")
+				return err
+			},
+			expectedErr:    ErrUnexpectedCall,
+			expectedErrors: 1,
+		},
+		{
+			name: "it should fail when expected call was not made",
+			givenExpect: func(m *Mock) {
+				m.Expect("Delete", "account-id").Times(2)
+			},
+			givenCall: func(m *Mock) error {
+				_, err := m.Delete(context.TODO(), "account-id")
+				return err
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "it should pass returned accounts to ListAll callback",
+			givenExpect: func(m *Mock) {
+				m.Expect("ListAll", Any).Return([]models.Account{{ID: "a"}, {ID: "b"}}, nil)
+			},
+			givenCall: func(m *Mock) error {
+				var ids []string
+				err := m.ListAll(context.TODO(), nil, func(acc *models.Account) error {
+					ids = append(ids, acc.ID)
+					return nil
+				})
+				if len(ids) != 2 {
+					return fmt.Errorf("unexpected ids %v", ids)
+				}
+				return err
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rt := &recordingT{}
+			m := NewMock(rt)
+			test.givenExpect(m)
+
+			err := test.givenCall(m)
+			m.AssertExpectations()
+
+			assert.Equal(t, test.expectedErr, err)
+			assert.Len(t, rt.errors, test.expectedErrors, rt.errors)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package clienttest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Reason codes returned by NameMatcher, following Confirmation of Payee scheme.
+const (
+	ReasonAccountNotFound     = "AC01"
+	ReasonWrongAccountType    = "ACNS"
+	ReasonOptedOut            = "OPTO"
+	ReasonCloseMatch          = "MBAM"
+	ReasonNoMatch             = "ANNM"
+	ReasonSecondaryIDMismatch = "SCNS"
+)
+
+// closeMatchThreshold is minimal similarity of two names to be reported as close match.
+const closeMatchThreshold = 0.8
+
+// honorifics are ignored when names are compared.
+var honorifics = map[string]bool{"mr": true, "mrs": true, "ms": true, "miss": true, "dr": true, "mx": true}
+
+// NameMatcher is client.NameVerifier matching names locally against accounts held by Fake.
+// Names are compared ignoring case, punctuation, honorifics and order of words; similar names and
+// names using initials are close matches.
+type NameMatcher struct {
+	accounts *Fake
+}
+
+var _ client.NameVerifier = (*NameMatcher)(nil)
+
+// NewNameMatcher creates NameMatcher verifying names of accounts held by given Fake.
+func NewNameMatcher(accounts *Fake) *NameMatcher {
+	return &NameMatcher{accounts: accounts}
+}
+
+// Verify implements client.NameVerifier.
+func (m *NameMatcher) Verify(ctx context.Context, verification *models.NameVerification) (*models.NameVerificationResult, *client.Response, error) {
+	req := verification.Attributes
+	account := m.find(req.BankID, req.AccountNumber)
+	switch {
+	case account == nil:
+		return &models.NameVerificationResult{Match: models.NameNoMatch, ReasonCode: ReasonAccountNotFound}, &client.Response{}, nil
+	case account.Attributes.AccountMatchingOptOut:
+		return &models.NameVerificationResult{Match: models.NameOptedOut, ReasonCode: ReasonOptedOut}, &client.Response{}, nil
+	case req.AccountClassification != "" && account.Attributes.AccountClassification != "" &&
+		req.AccountClassification != account.Attributes.AccountClassification:
+		return &models.NameVerificationResult{Match: models.NameNoMatch, ReasonCode: ReasonWrongAccountType}, &client.Response{}, nil
+	case account.Attributes.SecondaryIdentification != "" && req.SecondaryIdentification != account.Attributes.SecondaryIdentification:
+		return &models.NameVerificationResult{Match: models.NameNoMatch, ReasonCode: ReasonSecondaryIDMismatch}, &client.Response{}, nil
+	}
+
+	names := append([]string{account.Attributes.BankAccountName}, account.Attributes.AlternativeBankAccountNames...)
+	result := &models.NameVerificationResult{Match: models.NameNoMatch, ReasonCode: ReasonNoMatch}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		switch MatchName(name, req.Name) {
+		case models.NameFullMatch:
+			return &models.NameVerificationResult{Match: models.NameFullMatch}, &client.Response{}, nil
+		case models.NameCloseMatch:
+			if result.Match != models.NameCloseMatch {
+				result = &models.NameVerificationResult{Match: models.NameCloseMatch, SuggestedName: name, ReasonCode: ReasonCloseMatch}
+			}
+		}
+	}
+	return result, &client.Response{}, nil
+}
+
+func (m *NameMatcher) find(bankID, accountNumber string) *models.Account {
+	for _, acc := range m.accounts.Accounts() {
+		if acc.Attributes.BankID == bankID && acc.Attributes.AccountNumber == accountNumber {
+			return &acc
+		}
+	}
+	return nil
+}
+
+// MatchName compares name of account holder with given name. It returns full match if both names have
+// the same words, close match if words are similar or given name uses initials, and no match otherwise.
+func MatchName(holder, given string) models.NameMatch {
+	want, got := nameWords(holder), nameWords(given)
+	if len(want) == 0 || len(got) == 0 {
+		return models.NameNoMatch
+	}
+	if strings.Join(want, " ") == strings.Join(got, " ") {
+		return models.NameFullMatch
+	}
+	if initialsMatch(want, got) || similarity(strings.Join(want, " "), strings.Join(got, " ")) >= closeMatchThreshold {
+		return models.NameCloseMatch
+	}
+	return models.NameNoMatch
+}
+
+// apostrophes are removed from names, so "O'Neil" and "ONeil" are the same word.
+var apostrophes = strings.NewReplacer("'", "", "’", "")
+
+// nameWords returns lower cased words of name without punctuation and honorifics, sorted.
+func nameWords(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(apostrophes.Replace(name)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := fields[:0]
+	for _, w := range fields {
+		if !honorifics[w] {
+			words = append(words, w)
+		}
+	}
+	sort.Strings(words)
+	return words
+}
+
+// initialsMatch reports whether every word of got equals a word of want or is its initial,
+// and both names have the same number of words. Whole words are paired first, so an initial
+// does not take a word which is given in full, e.g. "J Jane" matches "Jane Jones".
+func initialsMatch(want, got []string) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	used := make([]bool, len(want))
+	var initials []string
+	for _, g := range got {
+		if !pairWord(want, used, func(w string) bool { return w == g }) {
+			initials = append(initials, g)
+		}
+	}
+	for _, g := range initials {
+		if len(g) != 1 || !pairWord(want, used, func(w string) bool { return strings.HasPrefix(w, g) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// pairWord marks the first unused word of want accepted by match as used and reports whether there was one.
+func pairWord(want []string, used []bool, match func(string) bool) bool {
+	for i, w := range want {
+		if !used[i] && match(w) {
+			used[i] = true
+			return true
+		}
+	}
+	return false
+}
+
+// similarity returns 1 for equal strings down to 0 for completely different ones, based on Levenshtein distance.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
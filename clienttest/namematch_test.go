@@ -0,0 +1,127 @@
+package clienttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchName(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenHolder   string
+		givenName     string
+		expectedMatch models.NameMatch
+	}{
+		{
+			name:          "it should fully match name ignoring case, punctuation and honorifics",
+			givenHolder:   "Samantha O'Neil",
+			givenName:     "mrs samantha oneil",
+			expectedMatch: models.NameFullMatch,
+		},
+		{
+			name:          "it should fully match name with reordered words",
+			givenHolder:   "Samantha Holder",
+			givenName:     "Mrs. Holder, Samantha",
+			expectedMatch: models.NameFullMatch,
+		},
+		{
+			name:          "it should closely match name using initials",
+			givenHolder:   "Samantha Holder",
+			givenName:     "S Holder",
+			expectedMatch: models.NameCloseMatch,
+		},
+		{
+			name:          "it should closely match initial of word sorted before its full form",
+			givenHolder:   "Jane Jones",
+			givenName:     "J Jane",
+			expectedMatch: models.NameCloseMatch,
+		},
+		{
+			name:          "it should closely match misspelled name",
+			givenHolder:   "Samantha Holder",
+			givenName:     "Samanta Holdr",
+			expectedMatch: models.NameCloseMatch,
+		},
+		{
+			name:          "it should not match different name",
+			givenHolder:   "Samantha Holder",
+			givenName:     "John Smith",
+			expectedMatch: models.NameNoMatch,
+		},
+		{
+			name:          "it should not match empty name",
+			givenHolder:   "Samantha Holder",
+			givenName:     "Mr",
+			expectedMatch: models.NameNoMatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedMatch, MatchName(test.givenHolder, test.givenName))
+		})
+	}
+}
+
+func TestNameMatcher_Verify(t *testing.T) {
+	holder := models.Account{ID: "holder", Attributes: models.AccountAttributes{
+		BankID:                      "400302",
+		AccountNumber:               "10000004",
+		BankAccountName:             "Samantha Holder",
+		AlternativeBankAccountNames: []string{"Sam Holder"},
+		AccountClassification:       "Personal",
+	}}
+	optedOut := models.Account{ID: "opted-out", Attributes: models.AccountAttributes{
+		BankID:                "400302",
+		AccountNumber:         "10000005",
+		BankAccountName:       "Sam Holder",
+		AccountMatchingOptOut: true,
+	}}
+	matcher := NewNameMatcher(NewFake(holder, optedOut))
+
+	tests := []struct {
+		name           string
+		givenRequest   models.NameVerificationAttributes
+		expectedResult models.NameVerificationResult
+	}{
+		{
+			name:           "it should fully match alternative name",
+			givenRequest:   models.NameVerificationAttributes{Name: "Sam Holder", BankID: "400302", AccountNumber: "10000004"},
+			expectedResult: models.NameVerificationResult{Match: models.NameFullMatch},
+		},
+		{
+			name:         "it should suggest name on close match",
+			givenRequest: models.NameVerificationAttributes{Name: "Samanta Holder", BankID: "400302", AccountNumber: "10000004"},
+			expectedResult: models.NameVerificationResult{
+				Match:         models.NameCloseMatch,
+				SuggestedName: "Samantha Holder",
+				ReasonCode:    ReasonCloseMatch,
+			},
+		},
+		{
+			name:           "it should not match unknown account",
+			givenRequest:   models.NameVerificationAttributes{Name: "Sam Holder", BankID: "400302", AccountNumber: "99999999"},
+			expectedResult: models.NameVerificationResult{Match: models.NameNoMatch, ReasonCode: ReasonAccountNotFound},
+		},
+		{
+			name:           "it should not match wrong account type",
+			givenRequest:   models.NameVerificationAttributes{Name: "Sam Holder", BankID: "400302", AccountNumber: "10000004", AccountClassification: "Business"},
+			expectedResult: models.NameVerificationResult{Match: models.NameNoMatch, ReasonCode: ReasonWrongAccountType},
+		},
+		{
+			name:           "it should report opted out account",
+			givenRequest:   models.NameVerificationAttributes{Name: "Sam Holder", BankID: "400302", AccountNumber: "10000005"},
+			expectedResult: models.NameVerificationResult{Match: models.NameOptedOut, ReasonCode: ReasonOptedOut},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, _, err := matcher.Verify(context.TODO(), &models.NameVerification{Attributes: test.givenRequest})
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, *result)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/rhymond/interview-accountapi/notification"
+)
+
+// SubscriptionServer is a fake of the subscriptions API. Notifications passed to Publish are delivered
+// to HTTP callbacks of matching subscriptions, signed the same way notification.HMACVerifier expects.
+//
+//	receiver := notificationtest.NewReceiver(secret, "accounts")
+//	server := clienttest.NewSubscriptionServer(secret)
+//	// create subscription with receiver.URL as callback URI using client pointed at server.URL
+//	server.Publish(ctx, envelope)
+//	envelope, err := receiver.Next(ctx)
+type SubscriptionServer struct {
+	// URL is base URL of the fake API.
+	URL string
+
+	server *httptest.Server
+	secret []byte
+
+	mu            sync.Mutex
+	subscriptions map[string]models.Subscription
+}
+
+// NewSubscriptionServer starts fake subscriptions API signing delivered notifications with secret.
+// Notifications are not signed if secret is nil.
+func NewSubscriptionServer(secret []byte) *SubscriptionServer {
+	s := &SubscriptionServer{secret: secret, subscriptions: make(map[string]models.Subscription)}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/notification/subscriptions", s.create).Methods(http.MethodPost)
+	router.HandleFunc("/v1/notification/subscriptions", s.list).Methods(http.MethodGet)
+	router.HandleFunc("/v1/notification/subscriptions/{id}", s.fetch).Methods(http.MethodGet)
+	router.HandleFunc("/v1/notification/subscriptions/{id}", s.delete).Methods(http.MethodDelete)
+
+	s.server = httptest.NewServer(router)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close stops the server.
+func (s *SubscriptionServer) Close() {
+	s.server.Close()
+}
+
+// Subscriptions returns all subscriptions ordered by ID.
+func (s *SubscriptionServer) Subscriptions() []models.Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscriptions := make([]models.Subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].ID < subscriptions[j].ID
+	})
+	return subscriptions
+}
+
+// Publish delivers envelope to active HTTP subscriptions of its record and event type and returns
+// number of deliveries. Queue subscriptions are skipped. It fails on the first rejected delivery.
+func (s *SubscriptionServer) Publish(ctx context.Context, envelope *notification.Envelope) (int, error) {
+	delivered := 0
+	for _, sub := range s.Subscriptions() {
+		attrs := sub.Attributes
+		if attrs.Deactivated || attrs.CallbackTransport != models.TransportHTTP || attrs.RecordType != envelope.RecordType ||
+			(attrs.EventType != "" && attrs.EventType != envelope.EventType) {
+			continue
+		}
+
+		req, err := notification.NewRequest(attrs.CallbackURI, envelope, s.secret)
+		if err != nil {
+			return delivered, err
+		}
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return delivered, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return delivered, fmt.Errorf("delivery to %s failed with status %d", attrs.CallbackURI, resp.StatusCode)
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+func (s *SubscriptionServer) create(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Data models.Subscription `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sub := req.Data
+	switch {
+	case sub.ID == "":
+		writeError(w, http.StatusBadRequest, "id in body is required")
+		return
+	case sub.Attributes.CallbackURI == "":
+		writeError(w, http.StatusBadRequest, "callback_uri in body is required")
+		return
+	case sub.Attributes.RecordType == "":
+		writeError(w, http.StatusBadRequest, "record_type in body is required")
+		return
+	case sub.Attributes.CallbackTransport != models.TransportHTTP && sub.Attributes.CallbackTransport != models.TransportQueue:
+		writeError(w, http.StatusBadRequest, "callback_transport must be http or queue")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscriptions[sub.ID]; ok {
+		writeError(w, http.StatusConflict, "Subscription cannot be created as it violates a duplicate constraint")
+		return
+	}
+	sub.Version = 0
+	s.subscriptions[sub.ID] = sub
+	writeData(w, http.StatusCreated, sub)
+}
+
+func (s *SubscriptionServer) list(w http.ResponseWriter, r *http.Request) {
+	recordType := r.URL.Query().Get("filter[record_type]")
+	eventType := r.URL.Query().Get("filter[event_type]")
+
+	subscriptions := []models.Subscription{}
+	for _, sub := range s.Subscriptions() {
+		if (recordType == "" || sub.Attributes.RecordType == recordType) && (eventType == "" || sub.Attributes.EventType == eventType) {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	writeData(w, http.StatusOK, subscriptions)
+}
+
+func (s *SubscriptionServer) fetch(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	s.mu.Lock()
+	sub, ok := s.subscriptions[id]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	writeData(w, http.StatusOK, sub)
+}
+
+func (s *SubscriptionServer) delete(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.subscriptions[id]
+	switch {
+	case !ok:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case sub.Version != version:
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+	delete(s.subscriptions, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeData(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Data interface{} `json:"data"`
+	}{data})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Message string `json:"error_message"`
+	}{message})
+}
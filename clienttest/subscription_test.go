@@ -0,0 +1,109 @@
+package clienttest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/rhymond/interview-accountapi/notification"
+	"github.com/rhymond/interview-accountapi/notificationtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("secret")
+
+func newSubscriptionClient(t *testing.T, server *SubscriptionServer) *client.Client {
+	u, err := url.Parse(server.URL)
+	require.Nil(t, err)
+	return client.NewClient(nil, u)
+}
+
+func TestSubscriptionServer_Delivery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	receiver := notificationtest.NewReceiver(testSecret, notification.RecordTypeAccounts)
+	defer receiver.Close()
+	server := NewSubscriptionServer(testSecret)
+	defer server.Close()
+	c := newSubscriptionClient(t, server)
+
+	subscriptions := []models.Subscription{
+		{ID: "http", Attributes: models.SubscriptionAttributes{CallbackURI: receiver.URL, CallbackTransport: models.TransportHTTP, RecordType: "accounts"}},
+		{ID: "queue", Attributes: models.SubscriptionAttributes{CallbackURI: "queue-name", CallbackTransport: models.TransportQueue, RecordType: "accounts"}},
+		{ID: "payments", Attributes: models.SubscriptionAttributes{CallbackURI: receiver.URL, CallbackTransport: models.TransportHTTP, RecordType: "payments"}},
+		{ID: "deleted", Attributes: models.SubscriptionAttributes{CallbackURI: receiver.URL, CallbackTransport: models.TransportHTTP, RecordType: "accounts", EventType: "deleted"}},
+	}
+	for i := range subscriptions {
+		_, _, err := c.Subscription.Create(ctx, &subscriptions[i])
+		require.Nil(t, err)
+	}
+
+	envelope, err := notificationtest.NewAccountEnvelope(notification.EventCreated, &models.Account{ID: "account-id"})
+	require.Nil(t, err)
+	delivered, err := server.Publish(ctx, envelope)
+	require.Nil(t, err)
+	assert.Equal(t, 1, delivered, "it should deliver only to http subscription of matching record and event type")
+
+	received, err := receiver.Next(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, envelope.ID, received.ID)
+}
+
+func TestSubscriptionServer_Lifecycle(t *testing.T) {
+	ctx := context.TODO()
+	server := NewSubscriptionServer(nil)
+	defer server.Close()
+	c := newSubscriptionClient(t, server)
+
+	sub := &models.Subscription{ID: "a", Attributes: models.SubscriptionAttributes{
+		CallbackURI: "https://example.com", CallbackTransport: models.TransportHTTP, RecordType: "accounts",
+	}}
+	_, _, err := c.Subscription.Create(ctx, sub)
+	require.Nil(t, err)
+
+	_, _, err = c.Subscription.Create(ctx, sub)
+	assertStatus(t, http.StatusConflict, err)
+
+	_, _, err = c.Subscription.Create(ctx, &models.Subscription{ID: "b", Attributes: models.SubscriptionAttributes{
+		CallbackURI: "https://example.com", CallbackTransport: "email", RecordType: "accounts",
+	}})
+	assertStatus(t, http.StatusBadRequest, err)
+
+	subs, _, err := c.Subscription.List(ctx, &client.SubscriptionListOptions{Filter: &client.SubscriptionFilter{RecordType: "payments"}})
+	require.Nil(t, err)
+	assert.Len(t, subs, 0)
+
+	_, err = c.Subscription.Delete(ctx, "a", 1)
+	assertStatus(t, http.StatusConflict, err)
+	_, err = c.Subscription.Delete(ctx, "a", 0)
+	require.Nil(t, err)
+
+	_, _, err = c.Subscription.Fetch(ctx, "a")
+	assertStatus(t, http.StatusNotFound, err)
+	assert.Len(t, server.Subscriptions(), 0)
+}
+
+func TestSubscriptionServer_PublishRejected(t *testing.T) {
+	receiver := notificationtest.NewReceiver([]byte("other"), notification.RecordTypeAccounts)
+	defer receiver.Close()
+	server := NewSubscriptionServer(testSecret)
+	defer server.Close()
+	c := newSubscriptionClient(t, server)
+
+	_, _, err := c.Subscription.Create(context.TODO(), &models.Subscription{ID: "a", Attributes: models.SubscriptionAttributes{
+		CallbackURI: receiver.URL, CallbackTransport: models.TransportHTTP, RecordType: "accounts",
+	}})
+	require.Nil(t, err)
+
+	envelope, err := notificationtest.NewAccountEnvelope(notification.EventCreated, &models.Account{ID: "account-id"})
+	require.Nil(t, err)
+	delivered, err := server.Publish(context.TODO(), envelope)
+	assert.Equal(t, 0, delivered)
+	assert.EqualError(t, err, "delivery to "+receiver.URL+" failed with status 401")
+}
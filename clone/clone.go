@@ -0,0 +1,177 @@
+// Package clone copies accounts between account API instances, e.g. to mirror a sample of production accounts in staging.
+package clone
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Lister pages through accounts. It is implemented by client.AccountService.
+type Lister interface {
+	ListAll(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) error
+}
+
+// Creator creates accounts. It is implemented by client.AccountService.
+type Creator interface {
+	Create(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error)
+}
+
+// Copied is an account created in target.
+type Copied struct {
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+}
+
+// Conflict is an account which target refused to create because it already exists.
+type Conflict struct {
+	SourceID string `json:"source_id"`
+	TargetID string `json:"target_id"`
+	Message  string `json:"message"`
+}
+
+// Report lists outcome of cloning every source account.
+type Report struct {
+	Copied    []Copied   `json:"copied"`
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// errLimitReached stops listing source accounts once enough accounts were cloned.
+var errLimitReached = errors.New("limit reached")
+
+// Cloner reads accounts from source and recreates them in target.
+type Cloner struct {
+	source Lister
+	target Creator
+
+	// Filter limits source accounts.
+	Filter *client.AccountFilter
+	// Limit caps number of source accounts which are cloned. Zero means all accounts.
+	Limit int
+	// RemapID returns ID of account in target. IDs are kept if it is nil.
+	RemapID func(sourceID string) string
+	// OrganisationID replaces organisation of cloned accounts, unless empty.
+	OrganisationID string
+	// Scrub replaces personal data of cloned accounts with fake values, see Scrub.
+	Scrub bool
+	// ScrubSecret keys fake values. If it is empty, a random secret is generated for every Clone,
+	// so values are scrubbed consistently within a single run only.
+	ScrubSecret []byte
+}
+
+// New creates Cloner copying accounts from source to target.
+func New(source Lister, target Creator) *Cloner {
+	return &Cloner{source: source, target: target}
+}
+
+// Clone copies source accounts to target. Accounts which already exist in target are reported as conflicts;
+// any other error stops cloning and is returned together with report of accounts cloned so far.
+func (c *Cloner) Clone(ctx context.Context) (*Report, error) {
+	secret, err := c.scrubSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Copied: []Copied{}, Conflicts: []Conflict{}}
+	seen := 0
+	err = c.source.ListAll(ctx, &client.AccountListOptions{Filter: c.Filter}, func(acc *models.Account) error {
+		if c.Limit > 0 && seen >= c.Limit {
+			return errLimitReached
+		}
+		seen++
+
+		account := c.prepare(acc, secret)
+		created, _, err := c.target.Create(ctx, account)
+		var errResp *client.ErrorResponse
+		switch {
+		case errors.As(err, &errResp) && errResp.StatusCode == http.StatusConflict:
+			report.Conflicts = append(report.Conflicts, Conflict{SourceID: acc.ID, TargetID: account.ID, Message: errResp.Message})
+			return nil
+		case err != nil:
+			return err
+		}
+		report.Copied = append(report.Copied, Copied{SourceID: acc.ID, TargetID: created.ID})
+		return nil
+	})
+	if err != nil && err != errLimitReached {
+		return report, err
+	}
+	return report, nil
+}
+
+// scrubSecret returns secret used to scrub accounts, or nil if they are not scrubbed.
+func (c *Cloner) scrubSecret() ([]byte, error) {
+	if !c.Scrub || len(c.ScrubSecret) > 0 {
+		return c.ScrubSecret, nil
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// prepare returns copy of source account as it should be created in target. Related master account
+// and organisation are remapped the same way as ID and organisation of the account.
+func (c *Cloner) prepare(acc *models.Account, secret []byte) *models.Account {
+	account := *acc
+	account.Version = 0
+	account.Attributes.AlternativeBankAccountNames = append([]string(nil), acc.Attributes.AlternativeBankAccountNames...)
+	if acc.Attributes.SwitchedAccount != nil {
+		switched := *acc.Attributes.SwitchedAccount
+		account.Attributes.SwitchedAccount = &switched
+	}
+	if c.RemapID != nil {
+		account.ID = c.RemapID(acc.ID)
+	}
+	if c.OrganisationID != "" {
+		account.OrganisationID = c.OrganisationID
+	}
+	if acc.Relationships != nil {
+		account.Relationships = &models.AccountRelationships{
+			MasterAccount: c.relationship(acc.Relationships.MasterAccount, c.RemapID),
+			Organisation:  c.relationship(acc.Relationships.Organisation, c.remapOrganisation),
+		}
+	}
+	if c.Scrub {
+		Scrub(&account, secret)
+	}
+	return &account
+}
+
+// relationship returns copy of rel pointing at resource with ID changed by remap, unless remap is nil.
+func (c *Cloner) relationship(rel *models.Relationship, remap func(string) string) *models.Relationship {
+	if rel == nil {
+		return nil
+	}
+	if rel.Data == nil {
+		return &models.Relationship{}
+	}
+	id := rel.Data.ID
+	if remap != nil {
+		id = remap(id)
+	}
+	return models.NewRelationship(rel.Data.Type, id)
+}
+
+// remapOrganisation returns organisation of cloned accounts.
+func (c *Cloner) remapOrganisation(id string) string {
+	if c.OrganisationID != "" {
+		return c.OrganisationID
+	}
+	return id
+}
+
+// DeterministicIDs returns RemapID function deriving target IDs from source IDs and namespace,
+// so cloning the same accounts again reports conflicts instead of creating duplicates.
+func DeterministicIDs(namespace string) func(sourceID string) string {
+	ns := uuid.NewSHA1(uuid.NameSpaceURL, []byte(namespace))
+	return func(sourceID string) string {
+		return uuid.NewSHA1(ns, []byte(sourceID)).String()
+	}
+}
@@ -0,0 +1,110 @@
+package clone
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/clienttest"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sourceAccount(id string) models.Account {
+	return models.Account{
+		ID:             id,
+		OrganisationID: "production",
+		Type:           "accounts",
+		Version:        4,
+		Attributes:     models.AccountAttributes{Country: "GB", AccountNumber: "41426819", FirstName: "Sam"},
+	}
+}
+
+func TestCloner_Clone(t *testing.T) {
+	source := clienttest.NewFake(sourceAccount("a"), sourceAccount("b"), sourceAccount("c"))
+	target := clienttest.NewFake(models.Account{ID: "b"})
+
+	cloner := New(source, target)
+	cloner.OrganisationID = "staging"
+	cloner.Scrub = true
+	cloner.ScrubSecret = []byte("secret")
+	report, err := cloner.Clone(context.TODO())
+	require.Nil(t, err)
+
+	assert.Equal(t, []Copied{{SourceID: "a", TargetID: "a"}, {SourceID: "c", TargetID: "c"}}, report.Copied)
+	require.Len(t, report.Conflicts, 1)
+	assert.Equal(t, "b", report.Conflicts[0].SourceID)
+
+	cloned, _, err := target.Fetch(context.TODO(), "a")
+	require.Nil(t, err)
+	assert.Equal(t, "staging", cloned.OrganisationID)
+	assert.Equal(t, 0, cloned.Version)
+	assert.NotEqual(t, "41426819", cloned.Attributes.AccountNumber)
+	assert.NotEqual(t, "Sam", cloned.Attributes.FirstName)
+	scrubbed := sourceAccount("a")
+	Scrub(&scrubbed, cloner.ScrubSecret)
+	assert.Equal(t, scrubbed.Attributes.AccountNumber, cloned.Attributes.AccountNumber)
+
+	original, _, err := source.Fetch(context.TODO(), "a")
+	require.Nil(t, err)
+	assert.Equal(t, "41426819", original.Attributes.AccountNumber)
+}
+
+func TestCloner_CloneRemapAndLimit(t *testing.T) {
+	source := clienttest.NewFake(sourceAccount("a"), sourceAccount("b"), sourceAccount("c"))
+	target := clienttest.NewFake()
+
+	cloner := New(source, target)
+	cloner.Limit = 2
+	cloner.RemapID = DeterministicIDs("staging")
+	report, err := cloner.Clone(context.TODO())
+	require.Nil(t, err)
+	require.Len(t, report.Copied, 2)
+	assert.Equal(t, DeterministicIDs("staging")("a"), report.Copied[0].TargetID)
+	assert.NotEqual(t, "a", report.Copied[0].TargetID)
+
+	report, err = cloner.Clone(context.TODO())
+	require.Nil(t, err)
+	assert.Empty(t, report.Copied)
+	assert.Len(t, report.Conflicts, 2)
+}
+
+func TestCloner_CloneRelationships(t *testing.T) {
+	virtual := sourceAccount("virtual")
+	virtual.Relationships = &models.AccountRelationships{
+		MasterAccount: models.NewRelationship("accounts", "master"),
+		Organisation:  models.NewRelationship("organisations", "production"),
+	}
+	source := clienttest.NewFake(virtual)
+	target := clienttest.NewFake()
+
+	cloner := New(source, target)
+	cloner.OrganisationID = "staging"
+	cloner.RemapID = DeterministicIDs("staging")
+	_, err := cloner.Clone(context.TODO())
+	require.Nil(t, err)
+
+	cloned, _, err := target.Fetch(context.TODO(), DeterministicIDs("staging")("virtual"))
+	require.Nil(t, err)
+	assert.Equal(t, models.NewRelationship("accounts", DeterministicIDs("staging")("master")), cloned.Relationships.MasterAccount)
+	assert.Equal(t, models.NewRelationship("organisations", "staging"), cloned.Relationships.Organisation)
+
+	original, _, err := source.Fetch(context.TODO(), "virtual")
+	require.Nil(t, err)
+	assert.Equal(t, "master", original.Relationships.MasterAccount.Data.ID)
+	assert.Equal(t, "production", original.Relationships.Organisation.Data.ID)
+}
+
+func TestCloner_CloneError(t *testing.T) {
+	m := clienttest.NewMock(t)
+	failure := &client.ErrorResponse{StatusCode: http.StatusInternalServerError, Message: "boom"}
+	m.Expect("Create", clienttest.Any).Return(nil, nil, failure)
+	defer m.AssertExpectations()
+
+	report, err := New(clienttest.NewFake(sourceAccount("a"), sourceAccount("b")), m).Clone(context.TODO())
+	assert.True(t, errors.Is(err, failure))
+	assert.Empty(t, report.Copied)
+}
@@ -0,0 +1,98 @@
+package clone
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Scrub replaces personal data of account, i.e. names, account numbers and IBANs, with fake values.
+// Account number of switched account is replaced too.
+// Values are derived from HMAC-SHA256 of the original value keyed by secret, so the same input is scrubbed
+// the same way with the same secret, but original values cannot be recovered by hashing guesses without it.
+// Scrubbed accounts keep their format: account numbers keep length and IBANs stay valid.
+func Scrub(account *models.Account, secret []byte) {
+	s := scrubber{secret: secret}
+	attrs := &account.Attributes
+	if attrs.FirstName != "" {
+		attrs.FirstName = s.fakeName("First", attrs.FirstName)
+	}
+	if attrs.BankAccountName != "" {
+		attrs.BankAccountName = s.fakeName("Account Holder", attrs.BankAccountName)
+	}
+	for i, name := range attrs.AlternativeBankAccountNames {
+		attrs.AlternativeBankAccountNames[i] = s.fakeName("Account Holder", name)
+	}
+	if attrs.SecondaryIdentification != "" {
+		attrs.SecondaryIdentification = s.fakeDigits(attrs.SecondaryIdentification)
+	}
+	if attrs.AccountNumber != "" {
+		attrs.AccountNumber = s.fakeDigits(attrs.AccountNumber)
+	}
+	if attrs.Iban != "" {
+		attrs.Iban = s.fakeIban(attrs.Iban)
+	}
+	if attrs.SwitchedAccount != nil && attrs.SwitchedAccount.AccountNumber != "" {
+		attrs.SwitchedAccount.AccountNumber = s.fakeDigits(attrs.SwitchedAccount.AccountNumber)
+	}
+}
+
+// scrubber derives fake values from HMAC of original values.
+type scrubber struct {
+	secret []byte
+}
+
+// fakeName returns prefix followed by short hash of value.
+func (s scrubber) fakeName(prefix, value string) string {
+	return prefix + " " + hex.EncodeToString(s.sum(value))[:8]
+}
+
+// fakeDigits replaces digits of value with digits derived from its hash, keeping other characters.
+func (s scrubber) fakeDigits(value string) string {
+	sum := s.sum(value)
+	out := []byte(value)
+	for i, c := range out {
+		if c >= '0' && c <= '9' {
+			out[i] = '0' + sum[i%len(sum)]%10
+		}
+	}
+	return string(out)
+}
+
+// fakeIban scrubs digits of basic bank account number and recomputes check digits.
+// Letters, e.g. bank code of GB IBANs, are kept.
+func (s scrubber) fakeIban(iban string) string {
+	iban = strings.ToUpper(strings.Replace(iban, " ", "", -1))
+	if len(iban) < 5 {
+		return s.fakeDigits(iban)
+	}
+	country, bban := iban[:2], s.fakeDigits(iban[4:])
+	return country + ibanCheckDigits(country, bban) + bban
+}
+
+// ibanCheckDigits computes ISO 13616 check digits using mod 97.
+func ibanCheckDigits(country, bban string) string {
+	var digits strings.Builder
+	for _, c := range bban + country + "00" {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			digits.WriteString(strconv.Itoa(int(c - 'A' + 10)))
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	check := 98 - new(big.Int).Mod(n, big.NewInt(97)).Int64()
+	return string([]byte{'0' + byte(check/10), '0' + byte(check%10)})
+}
+
+func (s scrubber) sum(value string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,68 @@
+package clone
+
+import (
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrub(t *testing.T) {
+	account := models.Account{Attributes: models.AccountAttributes{
+		Country:                     "GB",
+		BankID:                      "400302",
+		AccountNumber:               "41426819",
+		Iban:                        "GB11NWBK40030041426819",
+		FirstName:                   "Samantha",
+		BankAccountName:             "Samantha Holder",
+		AlternativeBankAccountNames: []string{"Sam Holder"},
+		SwitchedAccount:             &models.SwitchedAccount{BankID: "400300", AccountNumber: "10000004"},
+	}}
+	secret := []byte("secret")
+	Scrub(&account, secret)
+
+	attrs := account.Attributes
+	assert.Equal(t, "400302", attrs.BankID)
+	assert.NotEqual(t, "41426819", attrs.AccountNumber)
+	assert.Regexp(t, `^[0-9]{8}$`, attrs.AccountNumber)
+	assert.Regexp(t, `^GB[0-9]{2}NWBK[0-9]{14}$`, attrs.Iban)
+	assert.Equal(t, attrs.Iban[2:4], ibanCheckDigits("GB", attrs.Iban[4:]))
+	assert.NotEqual(t, "GB11NWBK40030041426819", attrs.Iban)
+	assert.Regexp(t, `^First [0-9a-f]{8}$`, attrs.FirstName)
+	assert.Regexp(t, `^Account Holder [0-9a-f]{8}$`, attrs.BankAccountName)
+	assert.Regexp(t, `^Account Holder [0-9a-f]{8}$`, attrs.AlternativeBankAccountNames[0])
+	assert.Equal(t, "400300", attrs.SwitchedAccount.BankID)
+	assert.NotEqual(t, "10000004", attrs.SwitchedAccount.AccountNumber)
+
+	again := models.Account{Attributes: models.AccountAttributes{AccountNumber: "41426819"}}
+	Scrub(&again, secret)
+	assert.Equal(t, attrs.AccountNumber, again.Attributes.AccountNumber, "it should scrub the same way with the same secret")
+
+	other := models.Account{Attributes: models.AccountAttributes{AccountNumber: "41426819"}}
+	Scrub(&other, []byte("other secret"))
+	assert.NotEqual(t, attrs.AccountNumber, other.Attributes.AccountNumber, "it should scrub differently with another secret")
+}
+
+func TestIbanCheckDigits(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenIban      string
+		expectedDigits string
+	}{
+		{
+			name:           "it should compute check digits of GB iban",
+			givenIban:      "GB29NWBK60161331926819",
+			expectedDigits: "29",
+		},
+		{
+			name:           "it should compute check digits of DE iban",
+			givenIban:      "DE89370400440532013000",
+			expectedDigits: "89",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedDigits, ibanCheckDigits(test.givenIban[:2], test.givenIban[4:]))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rhymond/interview-accountapi/apply"
+)
+
+func runApply(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "apply")
+	file := fs.String("f", "", "YAML or JSON file with desired accounts, - for stdin")
+	format := fs.String("format", "", "state format: yaml or json, detected from file extension if not given")
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+	prune := fs.Bool("prune", false, "delete accounts which are not in the file")
+	filter := registerFilterFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("state file is required")
+	}
+	if *format == "" {
+		*format = apply.Format(*file)
+	}
+
+	input, err := openInput(env, *file)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	state, err := apply.Decode(input, *format)
+	if err != nil {
+		return err
+	}
+
+	planner := apply.NewPlanner(env.client.Account)
+	planner.Prune = *prune
+	planner.Filter = filter
+	plan, err := planner.Plan(ctx, state)
+	if err != nil {
+		return err
+	}
+	if err := plan.Write(env.stdout); err != nil {
+		return err
+	}
+	if *dryRun || plan.Empty() {
+		return nil
+	}
+
+	applied, err := apply.Apply(ctx, env.client.Account, plan)
+	fmt.Fprintf(env.stdout, "applied %d of %d changes\n", applied, len(plan.Changes))
+	return err
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testState = `
+organisation_id: 743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb
+accounts:
+  - id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc
+    attributes: {country: GB}
+`
+
+func TestRun_Apply(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenArgs      []string
+		expectedCreate bool
+		expectedOutput string
+	}{
+		{
+			name:           "it should only print plan on dry run",
+			givenArgs:      []string{"-dry-run"},
+			expectedCreate: false,
+			expectedOutput: "+ create ad27e265-9605-4b4b-a0e5-3003ea9cc4dc\n" +
+				"plan: 1 to create, 0 to update, 0 to delete\n",
+		},
+		{
+			name:           "it should apply the plan",
+			givenArgs:      nil,
+			expectedCreate: true,
+			expectedOutput: "+ create ad27e265-9605-4b4b-a0e5-3003ea9cc4dc\n" +
+				"plan: 1 to create, 0 to update, 0 to delete\n" +
+				"applied 1 of 1 changes\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "accountctl")
+			require.Nil(t, err)
+			defer os.RemoveAll(dir)
+			input := filepath.Join(dir, "accounts.yaml")
+			require.Nil(t, ioutil.WriteFile(input, []byte(testState), 0644))
+
+			router, server, run := newTestAPI()
+			defer server.Close()
+			router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb", r.URL.Query().Get("filter[organisation_id]"))
+				fmt.Fprint(w, `{"data":[]}`)
+			}).Methods(http.MethodGet)
+			created := false
+			router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+				created = true
+				w.WriteHeader(http.StatusCreated)
+				body, _ := ioutil.ReadAll(r.Body)
+				w.Write(body)
+			}).Methods(http.MethodPost)
+
+			code, stdout, stderr := run(append([]string{"apply", "-f", input}, test.givenArgs...)...)
+			require.Equal(t, 0, code, stderr)
+			assert.Equal(t, test.expectedOutput, stdout)
+			assert.Equal(t, test.expectedCreate, created)
+		})
+	}
+}
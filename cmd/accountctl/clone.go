@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/clone"
+)
+
+func runClone(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "clone")
+	source := fs.String("source", "", "base URL of the API to copy accounts from; accounts are created in ACCOUNT_API_ADDR")
+	limit := fs.Int("limit", 0, "maximum number of accounts to copy, all if 0")
+	remapIDs := fs.String("remap-ids", "", "derive new account ids from source ids and given namespace")
+	organisationID := fs.String("organisation-id", "", "organisation id of created accounts, kept if not given")
+	scrub := fs.Bool("scrub", false, "replace names, account numbers and ibans with fake values")
+	output := fs.String("o", "text", "report format: text or json")
+	filter := registerFilterFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *source == "" {
+		return errors.New("source URL is required")
+	}
+	u, err := url.Parse(*source)
+	if err != nil {
+		return fmt.Errorf("invalid source URL: %v", err)
+	}
+
+	cloner := clone.New(client.NewClient(nil, u).Account, env.client.Account)
+	cloner.Filter = filter
+	cloner.Limit = *limit
+	cloner.OrganisationID = *organisationID
+	cloner.Scrub = *scrub
+	if *remapIDs != "" {
+		cloner.RemapID = clone.DeterministicIDs(*remapIDs)
+	}
+
+	report, err := cloner.Clone(ctx)
+	if report == nil {
+		return err
+	}
+	if writeErr := writeCloneReport(env, *output, report); writeErr != nil && err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+func writeCloneReport(env *environment, format string, report *clone.Report) error {
+	if format == "json" {
+		enc := json.NewEncoder(env.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	for _, c := range report.Conflicts {
+		fmt.Fprintf(env.stdout, "conflict %s -> %s: %s\n", c.SourceID, c.TargetID, c.Message)
+	}
+	_, err := fmt.Fprintf(env.stdout, "copied %d accounts, %d conflicts\n", len(report.Copied), len(report.Conflicts))
+	return err
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Clone(t *testing.T) {
+	source, sourceServer, _ := newTestAPI()
+	defer sourceServer.Close()
+	source.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":[%s]}`, testAccountJSON)
+	}).Methods(http.MethodGet)
+
+	router, server, run := newTestAPI()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data models.Account `json:"data"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "staging-id", req.Data.OrganisationID)
+		assert.NotEqual(t, "10000004", req.Data.Attributes.AccountNumber)
+
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"error_message":"Account cannot be created as it violates a duplicate constraint"}`)
+	}).Methods(http.MethodPost)
+
+	code, stdout, stderr := run("clone", "-source", sourceServer.URL, "-organisation-id", "staging-id", "-scrub")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "conflict account-id -> account-id: Account cannot be created as it violates a duplicate constraint\n"+
+		"copied 0 accounts, 1 conflicts\n", stdout)
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/uuid"
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// accountFlags holds flags describing account attributes shared by create and update commands.
+type accountFlags struct {
+	file           string
+	organisationID string
+	attributes     map[string]*string
+}
+
+// attributeSetters maps attribute flag names to functions setting them on account.
+var attributeSetters = map[string]func(attrs *models.AccountAttributes, value string){
+	"country":                func(a *models.AccountAttributes, v string) { a.Country = v },
+	"base-currency":          func(a *models.AccountAttributes, v string) { a.BaseCurrency = v },
+	"account-number":         func(a *models.AccountAttributes, v string) { a.AccountNumber = v },
+	"bank-id":                func(a *models.AccountAttributes, v string) { a.BankID = v },
+	"bank-id-code":           func(a *models.AccountAttributes, v string) { a.BankIDCode = v },
+	"bic":                    func(a *models.AccountAttributes, v string) { a.Bic = v },
+	"iban":                   func(a *models.AccountAttributes, v string) { a.Iban = v },
+	"title":                  func(a *models.AccountAttributes, v string) { a.Title = v },
+	"first-name":             func(a *models.AccountAttributes, v string) { a.FirstName = v },
+	"bank-account-name":      func(a *models.AccountAttributes, v string) { a.BankAccountName = v },
+	"account-classification": func(a *models.AccountAttributes, v string) { a.AccountClassification = v },
+}
+
+func registerAccountFlags(fs *flag.FlagSet) *accountFlags {
+	f := &accountFlags{attributes: make(map[string]*string)}
+	fs.StringVar(&f.file, "f", "", "read account JSON from file, - for stdin")
+	fs.StringVar(&f.organisationID, "organisation-id", "", "organisation id")
+	for name := range attributeSetters {
+		f.attributes[name] = fs.String(name, "", name+" attribute")
+	}
+	return f
+}
+
+// apply sets attributes given on the command line on the account, loading it from file first if requested.
+func (f *accountFlags) apply(env *environment, fs *flag.FlagSet, account *models.Account) error {
+	if f.file != "" {
+		if err := readAccountFile(env, f.file, account); err != nil {
+			return err
+		}
+	}
+
+	fs.Visit(func(fl *flag.Flag) {
+		if setter, ok := attributeSetters[fl.Name]; ok {
+			setter(&account.Attributes, *f.attributes[fl.Name])
+		}
+		if fl.Name == "organisation-id" {
+			account.OrganisationID = f.organisationID
+		}
+	})
+	return nil
+}
+
+func readAccountFile(env *environment, file string, account *models.Account) error {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = ioutil.ReadAll(env.stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, account)
+}
+
+// registerFilterFlags registers flags filtering listed accounts.
+func registerFilterFlags(fs *flag.FlagSet) *client.AccountFilter {
+	filter := &client.AccountFilter{}
+	fs.StringVar(&filter.BankIDCode, "bank-id-code", "", "filter by bank id code")
+	fs.StringVar(&filter.BankID, "bank-id", "", "filter by bank id")
+	fs.StringVar(&filter.AccountNumber, "account-number", "", "filter by account number")
+	fs.StringVar(&filter.Iban, "iban", "", "filter by iban")
+	fs.StringVar(&filter.CustomerID, "customer-id", "", "filter by customer id")
+	fs.StringVar(&filter.Country, "country", "", "filter by country")
+	return filter
+}
+
+// singleID returns the only positional argument of a command.
+func singleID(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() != 1 {
+		fmt.Fprintf(fs.Output(), "usage: accountctl %s [flags] <id>\n", fs.Name())
+		fs.PrintDefaults()
+		return "", errUsage
+	}
+	return fs.Arg(0), nil
+}
+
+func runCreate(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "create")
+	id := fs.String("id", "", "account id, generated if not given")
+	output := registerOutputFlag(fs)
+	accFlags := registerAccountFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	account := &models.Account{Type: "accounts"}
+	if err := accFlags.apply(env, fs, account); err != nil {
+		return err
+	}
+	if *id != "" {
+		account.ID = *id
+	}
+	if account.ID == "" {
+		account.ID = uuid.New().String()
+	}
+	if account.OrganisationID == "" {
+		return errors.New("organisation id is required")
+	}
+
+	created, _, err := env.client.Account.Create(ctx, account)
+	if err != nil {
+		return err
+	}
+	return writeAccounts(env.stdout, *output, []models.Account{*created})
+}
+
+func runFetch(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "fetch")
+	output := registerOutputFlag(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	id, err := singleID(fs)
+	if err != nil {
+		return err
+	}
+
+	account, _, err := env.client.Account.Fetch(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeAccounts(env.stdout, *output, []models.Account{*account})
+}
+
+func runList(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "list")
+	output := registerOutputFlag(fs)
+	opts := &client.AccountListOptions{Filter: registerFilterFlags(fs)}
+	all := fs.Bool("all", false, "follow next links and list all pages")
+	fs.IntVar(&opts.Page, "page", 0, "page number")
+	fs.IntVar(&opts.PerPage, "per-page", 0, "page size")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var (
+		accounts []models.Account
+		err      error
+	)
+	if *all {
+		err = env.client.Account.ListAll(ctx, opts, func(acc *models.Account) error {
+			accounts = append(accounts, *acc)
+			return nil
+		})
+	} else {
+		accounts, _, err = env.client.Account.ListWithOptions(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+	return writeAccounts(env.stdout, *output, accounts)
+}
+
+func runUpdate(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "update")
+	output := registerOutputFlag(fs)
+	accFlags := registerAccountFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	id, err := singleID(fs)
+	if err != nil {
+		return err
+	}
+
+	account, _, err := env.client.Account.Fetch(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := accFlags.apply(env, fs, account); err != nil {
+		return err
+	}
+	account.ID = id
+
+	updated, _, err := env.client.Account.Update(ctx, account)
+	if err != nil {
+		return err
+	}
+	return writeAccounts(env.stdout, *output, []models.Account{*updated})
+}
+
+func runDelete(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "delete")
+	version := fs.Int("version", -1, "current account version, fetched if not given")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	id, err := singleID(fs)
+	if err != nil {
+		return err
+	}
+
+	if *version < 0 {
+		account, _, err := env.client.Account.Fetch(ctx, id)
+		if err != nil {
+			return err
+		}
+		*version = account.Version
+	}
+
+	if _, err := env.client.Account.DeleteVersion(ctx, id, *version); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "deleted account %s\n", id)
+	return nil
+}
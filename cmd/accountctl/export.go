@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/export"
+)
+
+func runExport(ctx context.Context, env *environment, args []string) (err error) {
+	fs := newFlagSet(env, "export")
+	format := fs.String("format", "csv", "export format: csv, ndjson or columnar")
+	fields := fs.String("fields", "", "comma separated fields to export, all if not given: "+strings.Join(export.FieldNames(), ","))
+	file := fs.String("out", "-", "file to write export to, - for stdout")
+	opts := &client.AccountListOptions{Filter: registerFilterFlags(fs)}
+	fs.IntVar(&opts.PerPage, "per-page", 0, "page size used while listing accounts")
+	sort := fs.String("sort", "", "comma separated attributes to sort accounts by, prefix with - for descending order")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var selected []string
+	if *fields != "" {
+		for _, name := range strings.Split(*fields, ",") {
+			selected = append(selected, strings.TrimSpace(name))
+		}
+	}
+
+	opts.Fields = export.SparseFields(selected)
+	if *sort != "" {
+		opts.Sort = strings.Split(*sort, ",")
+	}
+
+	// Encoder is created before the output file, so invalid format or fields do not overwrite it.
+	out := &outputWriter{Writer: env.stdout}
+	enc, err := export.NewEncoder(*format, out, selected)
+	if err != nil {
+		return err
+	}
+	if *file != "-" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		out.Writer = f
+	}
+
+	count, err := export.Export(ctx, env.client.Account, opts, enc)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stderr, "exported %d accounts\n", count)
+	return nil
+}
+
+// outputWriter writes to Writer, which can be set after encoder writing to it was created.
+type outputWriter struct {
+	io.Writer
+}
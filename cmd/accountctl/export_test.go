@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Export(t *testing.T) {
+	router, server, run := newTestAPI()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "400302", r.URL.Query().Get("filter[bank_id]"))
+		assert.Equal(t, "account_number", r.URL.Query().Get("fields[accounts]"))
+		assert.Equal(t, "-bank_id,account_number", r.URL.Query().Get("sort"))
+		fmt.Fprintf(w, `{"data":[%s]}`, testAccountJSON)
+	}).Methods(http.MethodGet)
+
+	code, stdout, stderr := run("export", "-format", "ndjson", "-fields", "id, account_number", "-bank-id", "400302", "-sort", "-bank_id,account_number")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, `{"account_number":"10000004","id":"account-id"}`+"\n", stdout)
+	assert.Equal(t, "exported 1 accounts\n", stderr)
+}
+
+func TestRun_ExportInvalidFormat(t *testing.T) {
+	_, server, run := newTestAPI()
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "accounts.csv")
+	require.Nil(t, ioutil.WriteFile(file, []byte("previous export\n"), 0644))
+
+	code, _, stderr := run("export", "-format", "xml", "-out", file)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "export: unknown export format \"xml\"\n", stderr)
+	data, err := ioutil.ReadFile(file)
+	require.Nil(t, err)
+	assert.Equal(t, "previous export\n", string(data), "it should not overwrite file when format is invalid")
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rhymond/interview-accountapi/importer"
+)
+
+func runImport(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "import")
+	file := fs.String("f", "", "CSV or JSON file with accounts, - for stdin")
+	format := fs.String("format", "", "input format: csv or json, detected from file extension if not given")
+	mapping := fs.String("map", "", "CSV column mapping as column=field pairs separated by commas")
+	resultsFile := fs.String("results", "import-results.csv", "file to write per row results to")
+	resume := fs.Bool("resume", false, "skip rows created by previous run recorded in results file")
+	concurrency := fs.Int("concurrency", 4, "number of accounts created at once")
+	organisationID := fs.String("organisation-id", "", "organisation id for rows which do not specify it")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("input file is required")
+	}
+
+	input, err := openInput(env, *file)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	reader, err := newImportReader(input, inputFormat(*file, *format), *mapping)
+	if err != nil {
+		return err
+	}
+
+	im := importer.New(env.client.Account)
+	im.Concurrency = *concurrency
+	im.OrganisationID = *organisationID
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if *resume {
+		im.Previous, err = readPreviousResults(*resultsFile)
+		if err != nil {
+			return err
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	out, err := os.OpenFile(*resultsFile, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	info, err := out.Stat()
+	if err != nil {
+		return err
+	}
+	results, err := importer.NewResultWriter(out, info.Size() == 0)
+	if err != nil {
+		return err
+	}
+
+	summary, err := im.Import(ctx, reader, results)
+	printSummary(env.stdout, summary, *resultsFile)
+	if err != nil {
+		return err
+	}
+	if summary[importer.StatusFailed]+summary[importer.StatusInvalid] > 0 {
+		return errors.New("some rows were not imported")
+	}
+	return nil
+}
+
+func openInput(env *environment, file string) (io.ReadCloser, error) {
+	if file == "-" {
+		return ioutil.NopCloser(env.stdin), nil
+	}
+	return os.Open(file)
+}
+
+func inputFormat(file, format string) string {
+	if format != "" {
+		return format
+	}
+	if strings.HasSuffix(strings.ToLower(file), ".json") {
+		return "json"
+	}
+	return "csv"
+}
+
+func newImportReader(r io.Reader, format, mapping string) (importer.Reader, error) {
+	switch format {
+	case "json":
+		return importer.NewJSONReader(r), nil
+	case "csv":
+		m, err := parseMapping(mapping)
+		if err != nil {
+			return nil, err
+		}
+		return importer.NewCSVReader(r, m)
+	}
+	return nil, fmt.Errorf("unknown input format %q", format)
+}
+
+// parseMapping parses "column=field,column=field" into importer.Mapping.
+func parseMapping(s string) (importer.Mapping, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	m := importer.Mapping{}
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed mapping %q, expected column=field", pair)
+		}
+		m[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return m, nil
+}
+
+func readPreviousResults(file string) (map[int]importer.Result, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return importer.ReadResults(f)
+}
+
+func printSummary(w io.Writer, summary importer.Summary, resultsFile string) {
+	statuses := make([]string, 0, len(summary))
+	for status := range summary {
+		statuses = append(statuses, string(status))
+	}
+	sort.Strings(statuses)
+
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = fmt.Sprintf("%s: %d", status, summary[importer.Status(status)])
+	}
+	fmt.Fprintf(w, "%s (results written to %s)\n", strings.Join(parts, ", "), resultsFile)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Import(t *testing.T) {
+	dir, err := ioutil.TempDir("", "accountctl")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "accounts.csv")
+	results := filepath.Join(dir, "results.csv")
+	require.Nil(t, ioutil.WriteFile(input, []byte("Country,Account\nGB,1\nGB,2\n"), 0644))
+
+	router, server, run := newTestAPI()
+	defer server.Close()
+	failing := "2"
+	var created []string
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data models.Account `json:"data"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Data.Attributes.AccountNumber == failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error_message":"boom"}`))
+			return
+		}
+		created = append(created, req.Data.Attributes.AccountNumber)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(req)
+	}).Methods(http.MethodPost)
+
+	args := []string{
+		"import",
+		"-f", input,
+		"-map", "Country=country,Account=account_number",
+		"-organisation-id", "efab8098-d2e7-47f0-9db3-1c318920f71d",
+		"-results", results,
+		"-concurrency", "1",
+	}
+	code, stdout, _ := run(args...)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "created: 1, failed: 1 (results written to "+results+")\n", stdout)
+	assert.Equal(t, []string{"1"}, created)
+
+	failing = ""
+	code, stdout, stderr := run(append(args, "-resume")...)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "created: 1, skipped: 1 (results written to "+results+")\n", stdout)
+	assert.Equal(t, []string{"1", "2"}, created)
+}
+
+func TestParseMapping(t *testing.T) {
+	m, err := parseMapping("Sort code = bank_id, Country=country")
+	require.Nil(t, err)
+	assert.Equal(t, "bank_id", m["Sort code"])
+	assert.Equal(t, "country", m["Country"])
+
+	_, err = parseMapping("country")
+	assert.EqualError(t, err, `malformed mapping "country", expected column=field`)
+}
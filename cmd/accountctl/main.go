@@ -0,0 +1,127 @@
+// Command accountctl manages accounts of the account API from the command line.
+//
+// Usage:
+//
+//	accountctl <command> [flags]
+//
+// Base URL of the API is read from ACCOUNT_API_ADDR environment variable and defaults to http://localhost:8080.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+
+	"github.com/rhymond/interview-accountapi/client"
+)
+
+const defaultAddr = "http://localhost:8080"
+
+// command is a single accountctl subcommand.
+type command struct {
+	usage string
+	run   func(ctx context.Context, env *environment, args []string) error
+}
+
+// environment holds dependencies shared by all commands.
+type environment struct {
+	client *client.Client
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var commands = map[string]command{
+	"apply":     {usage: "create, update and delete accounts to match YAML or JSON file", run: runApply},
+	"clone":     {usage: "copy accounts from another API instance", run: runClone},
+	"create":    {usage: "create an account", run: runCreate},
+	"fetch":     {usage: "fetch an account by id", run: runFetch},
+	"list":      {usage: "list accounts", run: runList},
+	"update":    {usage: "update an account by id", run: runUpdate},
+	"delete":    {usage: "delete an account by id", run: runDelete},
+	"import":    {usage: "create accounts in bulk from CSV or JSON file", run: runImport},
+	"export":    {usage: "export all accounts to CSV, NDJSON or columnar JSON", run: runExport},
+	"reconcile": {usage: "compare accounts from CSV or JSON file with the API", run: runReconcile},
+}
+
+// errUsage is returned when command line is invalid and usage was already printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, getenv func(string) string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+
+	addr := getenv("ACCOUNT_API_ADDR")
+	if addr == "" {
+		addr = defaultAddr
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		fmt.Fprintf(stderr, "invalid ACCOUNT_API_ADDR: %v\n", err)
+		return 1
+	}
+
+	env := &environment{
+		client: client.NewClient(nil, u),
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+	err = cmd.run(ctx, env, args[1:])
+	switch {
+	case err == errUsage:
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "%s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: accountctl <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+// newFlagSet creates flag set for a command which reports errors to stderr.
+func newFlagSet(env *environment, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	return fs
+}
+
+// parseFlags parses command flags, mapping parse errors to errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
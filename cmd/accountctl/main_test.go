@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccountJSON = `{
+	"id": "account-id",
+	"organisation_id": "organisation-id",
+	"type": "accounts",
+	"version": 1,
+	"attributes": {"country": "GB", "bank_id": "400302", "account_number": "10000004"}
+}`
+
+func newTestAPI() (*mux.Router, *httptest.Server, func(args ...string) (int, string, string)) {
+	router := mux.NewRouter()
+	server := httptest.NewServer(router)
+
+	return router, server, func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		getenv := func(key string) string {
+			if key == "ACCOUNT_API_ADDR" {
+				return server.URL
+			}
+			return ""
+		}
+		code := run(context.TODO(), args, getenv, strings.NewReader(testAccountJSON), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	tests := []struct {
+		name         string
+		givenArgs    []string
+		expectedCode int
+	}{
+		{
+			name:         "it should print usage when no command is given",
+			givenArgs:    nil,
+			expectedCode: 2,
+		},
+		{
+			name:         "it should print usage on unknown command",
+			givenArgs:    []string{"explode"},
+			expectedCode: 2,
+		},
+		{
+			name:         "it should print usage when fetch id is missing",
+			givenArgs:    []string{"fetch"},
+			expectedCode: 2,
+		},
+		{
+			name:         "it should fail when organisation id is not given on create",
+			givenArgs:    []string{"create", "-country", "GB"},
+			expectedCode: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, server, run := newTestAPI()
+			defer server.Close()
+			code, _, stderr := run(test.givenArgs...)
+			assert.Equal(t, test.expectedCode, code)
+			assert.NotEmpty(t, stderr)
+		})
+	}
+}
+
+func TestRun_Create(t *testing.T) {
+	router, server, run := newTestAPI()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data models.Account `json:"data"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "organisation-id", req.Data.OrganisationID)
+		assert.Equal(t, "GB", req.Data.Attributes.Country)
+		assert.Equal(t, "12345678", req.Data.Attributes.AccountNumber)
+		assert.NotEmpty(t, req.Data.ID)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(req)
+	}).Methods(http.MethodPost)
+
+	code, stdout, stderr := run("create", "-o", "json", "-organisation-id", "organisation-id", "-country", "GB", "-account-number", "12345678")
+	require.Equal(t, 0, code, stderr)
+
+	var accounts []models.Account
+	require.Nil(t, json.Unmarshal([]byte(stdout), &accounts))
+	assert.Equal(t, "12345678", accounts[0].Attributes.AccountNumber)
+}
+
+func TestRun_Fetch(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenFormat    string
+		expectedOutput string
+	}{
+		{
+			name:        "it should print account as a table",
+			givenFormat: "table",
+			expectedOutput: "ID          ORGANISATION ID  COUNTRY  BANK ID  ACCOUNT NUMBER  IBAN  VERSION\n" +
+				"account-id  organisation-id  GB       400302   10000004              1\n",
+		},
+		{
+			name:        "it should print account as yaml",
+			givenFormat: "yaml",
+			expectedOutput: "- attributes:\n" +
+				"    account_number: \"10000004\"\n" +
+				"    bank_id: \"400302\"\n" +
+				"    country: GB\n" +
+				"  id: account-id\n" +
+				"  organisation_id: organisation-id\n" +
+				"  type: accounts\n" +
+				"  version: 1\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router, server, run := newTestAPI()
+			defer server.Close()
+			router.HandleFunc("/v1/organisation/accounts/account-id", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"data":%s}`, testAccountJSON)
+			}).Methods(http.MethodGet)
+
+			code, stdout, stderr := run("fetch", "-o", test.givenFormat, "account-id")
+			require.Equal(t, 0, code, stderr)
+			assert.Equal(t, test.expectedOutput, stdout)
+		})
+	}
+}
+
+func TestRun_FetchError(t *testing.T) {
+	router, server, run := newTestAPI()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error_message":"record missing does not exist"}`)
+	}).Methods(http.MethodGet)
+
+	code, _, stderr := run("fetch", "missing")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "fetch: code: 404, message: record missing does not exist\n", stderr)
+}
+
+func TestRun_ListAll(t *testing.T) {
+	router, server, run := newTestAPI()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GB", r.URL.Query().Get("filter[country]"))
+		assert.Equal(t, "1", r.URL.Query().Get("page[size]"))
+		if r.URL.Query().Get("page[number]") == "" {
+			fmt.Fprint(w, `{"data":[{"id":"a"}],"links":{"next":"/v1/organisation/accounts?page[number]=1&page[size]=1"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"b"}],"links":{}}`)
+	}).Methods(http.MethodGet)
+
+	code, stdout, stderr := run("list", "-o", "json", "-all", "-per-page", "1", "-country", "GB")
+	require.Equal(t, 0, code, stderr)
+
+	var accounts []models.Account
+	require.Nil(t, json.Unmarshal([]byte(stdout), &accounts))
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "a", accounts[0].ID)
+	assert.Equal(t, "b", accounts[1].ID)
+}
+
+func TestRun_Update(t *testing.T) {
+	router, server, run := newTestAPI()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts/account-id", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":%s}`, testAccountJSON)
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/v1/organisation/accounts/account-id", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data models.Account `json:"data"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, 1, req.Data.Version)
+		assert.Equal(t, "NWBKGB22", req.Data.Attributes.Bic)
+		assert.Equal(t, "400302", req.Data.Attributes.BankID)
+
+		req.Data.Version++
+		json.NewEncoder(w).Encode(req)
+	}).Methods(http.MethodPatch)
+
+	code, stdout, stderr := run("update", "-o", "json", "-bic", "NWBKGB22", "account-id")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"version": 2`)
+}
+
+func TestRun_Delete(t *testing.T) {
+	router, server, run := newTestAPI()
+	defer server.Close()
+	router.HandleFunc("/v1/organisation/accounts/account-id", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":%s}`, testAccountJSON)
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/v1/organisation/accounts/account-id", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("version"))
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodDelete)
+
+	code, stdout, stderr := run("delete", "account-id")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "deleted account account-id\n", stdout)
+}
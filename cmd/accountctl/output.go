@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/rhymond/interview-accountapi/models"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Output formats supported by -o flag.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+func registerOutputFlag(fs *flag.FlagSet) *string {
+	return fs.String("o", outputTable, "output format: table, json or yaml")
+}
+
+// writeAccounts writes accounts to w in given format.
+func writeAccounts(w io.Writer, format string, accounts []models.Account) error {
+	switch format {
+	case outputTable:
+		return writeTable(w, accounts)
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(accounts)
+	case outputYAML:
+		return writeYAML(w, accounts)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+func writeTable(w io.Writer, accounts []models.Account) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tORGANISATION ID\tCOUNTRY\tBANK ID\tACCOUNT NUMBER\tIBAN\tVERSION")
+	for _, acc := range accounts {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			acc.ID,
+			acc.OrganisationID,
+			acc.Attributes.Country,
+			acc.Attributes.BankID,
+			acc.Attributes.AccountNumber,
+			acc.Attributes.Iban,
+			acc.Version,
+		)
+	}
+	return tw.Flush()
+}
+
+// writeYAML writes accounts as YAML using the same field names as JSON API does.
+func writeYAML(w io.Writer, accounts []models.Account) error {
+	data, err := json.Marshal(accounts)
+	if err != nil {
+		return err
+	}
+
+	var generic []interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/reconcile"
+)
+
+func runReconcile(ctx context.Context, env *environment, args []string) error {
+	fs := newFlagSet(env, "reconcile")
+	file := fs.String("f", "", "CSV or JSON file with expected accounts, - for stdin")
+	format := fs.String("format", "", "input format: csv or json, detected from file extension if not given")
+	mapping := fs.String("map", "", "CSV column mapping as column=field pairs separated by commas")
+	output := fs.String("o", "text", "report format: text or json")
+	opts := &client.AccountListOptions{Filter: registerFilterFlags(fs)}
+	fs.IntVar(&opts.PerPage, "per-page", 0, "page size used while listing accounts")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("input file is required")
+	}
+	if *output != "text" && *output != "json" {
+		return fmt.Errorf("unknown report format %q", *output)
+	}
+
+	input, err := openInput(env, *file)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	reader, err := newImportReader(input, inputFormat(*file, *format), *mapping)
+	if err != nil {
+		return err
+	}
+
+	report, err := reconcile.Reconcile(ctx, reconcile.FromImport(reader), env.client.Account, opts)
+	if err != nil {
+		return err
+	}
+
+	if *output == "json" {
+		err = report.WriteJSON(env.stdout)
+	} else {
+		err = report.WriteText(env.stdout)
+	}
+	if err != nil {
+		return err
+	}
+	if !report.Clean() {
+		return errors.New("accounts differ")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Reconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenCSV       string
+		expectedCode   int
+		expectedOutput string
+	}{
+		{
+			name:           "it should succeed when accounts match",
+			givenCSV:       "id,organisation_id,country,bank_id,account_number\naccount-id,organisation-id,GB,400302,10000004\n",
+			expectedCode:   0,
+			expectedOutput: "matched: 1, missing: 0, extra: 0, mismatched: 0\n",
+		},
+		{
+			name:         "it should fail when accounts differ",
+			givenCSV:     "id,organisation_id,country,bank_id,account_number\nledger-id,organisation-id,GB,400302,10000004\n",
+			expectedCode: 1,
+			expectedOutput: "matched: 1, missing: 0, extra: 0, mismatched: 1\n" +
+				"\n" +
+				"mismatched (1):\n" +
+				"  ID          MATCHED BY    FIELD  EXPECTED     ACTUAL\n" +
+				"  account-id  bank_account  id     \"ledger-id\"  \"account-id\"\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "accountctl")
+			require.Nil(t, err)
+			defer os.RemoveAll(dir)
+			input := filepath.Join(dir, "ledger.csv")
+			require.Nil(t, ioutil.WriteFile(input, []byte(test.givenCSV), 0644))
+
+			router, server, run := newTestAPI()
+			defer server.Close()
+			router.HandleFunc("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"data":[%s]}`, testAccountJSON)
+			}).Methods(http.MethodGet)
+
+			code, stdout, stderr := run("reconcile", "-f", input)
+			assert.Equal(t, test.expectedCode, code, stderr)
+			assert.Equal(t, test.expectedOutput, stdout)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// listSeparator joins values of list fields in a single CSV cell, the same way importer splits them.
+const listSeparator = ";"
+
+// Encoder writes exported accounts. Close must be called after the last account to flush output.
+type Encoder interface {
+	Encode(acc *models.Account) error
+	Close() error
+}
+
+// CSVEncoder writes accounts as CSV with header and flattened attributes.
+type CSVEncoder struct {
+	csv    *csv.Writer
+	fields []field
+	header bool
+}
+
+// NewCSVEncoder creates CSVEncoder writing given fields, or all fields if none are given.
+func NewCSVEncoder(w io.Writer, fields []string) (*CSVEncoder, error) {
+	selected, err := selectFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVEncoder{csv: csv.NewWriter(w), fields: selected}, nil
+}
+
+// Encode implements Encoder.
+func (e *CSVEncoder) Encode(acc *models.Account) error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		record[i] = csvValue(f.value(acc))
+	}
+	return e.csv.Write(record)
+}
+
+// Close implements Encoder. Header is written even if there were no accounts.
+func (e *CSVEncoder) Close() error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *CSVEncoder) writeHeader() error {
+	e.header = true
+	header := make([]string, len(e.fields))
+	for i, f := range e.fields {
+		header[i] = f.name
+	}
+	return e.csv.Write(header)
+}
+
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case []string:
+		return strings.Join(v, listSeparator)
+	}
+	return fmt.Sprint(v)
+}
+
+// NDJSONEncoder writes one JSON object per line. Without field selection accounts are written
+// as returned by the API; with field selection as flat objects of selected fields.
+type NDJSONEncoder struct {
+	enc    *json.Encoder
+	fields []field
+}
+
+// NewNDJSONEncoder creates NDJSONEncoder.
+func NewNDJSONEncoder(w io.Writer, fields []string) (*NDJSONEncoder, error) {
+	var selected []field
+	if len(fields) > 0 {
+		var err error
+		if selected, err = selectFields(fields); err != nil {
+			return nil, err
+		}
+	}
+	return &NDJSONEncoder{enc: json.NewEncoder(w), fields: selected}, nil
+}
+
+// Encode implements Encoder.
+func (e *NDJSONEncoder) Encode(acc *models.Account) error {
+	if e.fields == nil {
+		return e.enc.Encode(acc)
+	}
+
+	obj := make(map[string]interface{}, len(e.fields))
+	for _, f := range e.fields {
+		obj[f.name] = f.value(acc)
+	}
+	return e.enc.Encode(obj)
+}
+
+// Close implements Encoder.
+func (e *NDJSONEncoder) Close() error {
+	return nil
+}
+
+// ColumnarEncoder writes accounts column by column as a single JSON document:
+//
+//	{"rows": 2, "columns": {"id": ["a", "b"], "version": [0, 1]}}
+//
+// Values of every column are stored together, which suits analytical tools, but whole export
+// is held in memory until Close.
+type ColumnarEncoder struct {
+	w       io.Writer
+	fields  []field
+	columns [][]interface{}
+	rows    int
+}
+
+// NewColumnarEncoder creates ColumnarEncoder.
+func NewColumnarEncoder(w io.Writer, fields []string) (*ColumnarEncoder, error) {
+	selected, err := selectFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &ColumnarEncoder{w: w, fields: selected, columns: make([][]interface{}, len(selected))}, nil
+}
+
+// Encode implements Encoder.
+func (e *ColumnarEncoder) Encode(acc *models.Account) error {
+	for i, f := range e.fields {
+		e.columns[i] = append(e.columns[i], f.value(acc))
+	}
+	e.rows++
+	return nil
+}
+
+// Close implements Encoder and writes the document. Columns are written in order of selected fields.
+func (e *ColumnarEncoder) Close() error {
+	columns := make(orderedColumns, len(e.fields))
+	for i, f := range e.fields {
+		if e.columns[i] == nil {
+			e.columns[i] = []interface{}{}
+		}
+		columns[i] = column{name: f.name, values: e.columns[i]}
+	}
+
+	return json.NewEncoder(e.w).Encode(struct {
+		Rows    int            `json:"rows"`
+		Columns orderedColumns `json:"columns"`
+	}{Rows: e.rows, Columns: columns})
+}
+
+type column struct {
+	name   string
+	values []interface{}
+}
+
+// orderedColumns is encoded as JSON object keeping order of its columns, which a map would not.
+type orderedColumns []column
+
+// MarshalJSON implements json.Marshaler.
+func (c orderedColumns) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, col := range c {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(col.name)
+		if err != nil {
+			return nil, err
+		}
+		values, err := json.Marshal(col.values)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(values)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// NewEncoder creates encoder for given format: csv, ndjson or columnar.
+func NewEncoder(format string, w io.Writer, fields []string) (Encoder, error) {
+	switch format {
+	case "csv":
+		return NewCSVEncoder(w, fields)
+	case "ndjson":
+		return NewNDJSONEncoder(w, fields)
+	case "columnar":
+		return NewColumnarEncoder(w, fields)
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
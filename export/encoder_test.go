@@ -0,0 +1,117 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAccounts = []models.Account{
+	{
+		ID:             "a",
+		OrganisationID: "org",
+		Type:           "accounts",
+		Version:        1,
+		Attributes: models.AccountAttributes{
+			Country:                     "GB",
+			BankID:                      "400302",
+			AlternativeBankAccountNames: []string{"Jane", "J, Doe"},
+			JointAccount:                true,
+		},
+	},
+	{
+		ID:         "b",
+		Type:       "accounts",
+		Attributes: models.AccountAttributes{Country: "FR"},
+	},
+}
+
+func encodeAll(t *testing.T, enc Encoder, accounts []models.Account) {
+	for i := range accounts {
+		require.Nil(t, enc.Encode(&accounts[i]))
+	}
+	require.Nil(t, enc.Close())
+}
+
+func TestNewEncoder(t *testing.T) {
+	fields := []string{"id", "version", "country", "alternative_bank_account_names", "joint_account"}
+	tests := []struct {
+		name           string
+		givenFormat    string
+		givenFields    []string
+		givenAccounts  []models.Account
+		expectedOutput string
+	}{
+		{
+			name:          "it should write csv with flattened attributes",
+			givenFormat:   "csv",
+			givenFields:   fields,
+			givenAccounts: testAccounts,
+			expectedOutput: "id,version,country,alternative_bank_account_names,joint_account\n" +
+				"a,1,GB,\"Jane;J, Doe\",true\n" +
+				"b,0,FR,,false\n",
+		},
+		{
+			name:           "it should write csv header even without accounts",
+			givenFormat:    "csv",
+			givenFields:    []string{"id", "iban"},
+			expectedOutput: "id,iban\n",
+		},
+		{
+			name:          "it should write selected fields as ndjson",
+			givenFormat:   "ndjson",
+			givenFields:   []string{"id", "country"},
+			givenAccounts: testAccounts,
+			expectedOutput: `{"country":"GB","id":"a"}` + "\n" +
+				`{"country":"FR","id":"b"}` + "\n",
+		},
+		{
+			name:          "it should write whole accounts as ndjson without field selection",
+			givenFormat:   "ndjson",
+			givenAccounts: testAccounts[1:],
+			expectedOutput: `{"attributes":{"country":"FR"},"id":"b","organisation_id":"","type":"accounts","version":0}` +
+				"\n",
+		},
+		{
+			name:          "it should write columnar json in order of fields",
+			givenFormat:   "columnar",
+			givenFields:   fields,
+			givenAccounts: testAccounts,
+			expectedOutput: `{"rows":2,"columns":{"id":["a","b"],"version":[1,0],"country":["GB","FR"],` +
+				`"alternative_bank_account_names":[["Jane","J, Doe"],[]],"joint_account":[true,false]}}` + "\n",
+		},
+		{
+			name:           "it should write empty columns without accounts",
+			givenFormat:    "columnar",
+			givenFields:    []string{"id"},
+			expectedOutput: `{"rows":0,"columns":{"id":[]}}` + "\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			enc, err := NewEncoder(test.givenFormat, buf, test.givenFields)
+			require.Nil(t, err)
+
+			encodeAll(t, enc, test.givenAccounts)
+			assert.Equal(t, test.expectedOutput, buf.String())
+		})
+	}
+}
+
+func TestNewEncoder_Errors(t *testing.T) {
+	_, err := NewEncoder("parquet", &bytes.Buffer{}, nil)
+	assert.EqualError(t, err, `unknown export format "parquet"`)
+
+	_, err = NewEncoder("csv", &bytes.Buffer{}, []string{"id", "colour"})
+	assert.EqualError(t, err, `unknown field "colour"`)
+}
+
+func TestFieldNames(t *testing.T) {
+	names := FieldNames()
+	assert.Equal(t, "id", names[0])
+	assert.Len(t, names, len(allFields))
+}
@@ -0,0 +1,30 @@
+// Package export dumps accounts to CSV, NDJSON or columnar JSON files.
+package export
+
+import (
+	"context"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Lister pages through accounts. It is implemented by client.AccountService.
+type Lister interface {
+	ListAll(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) error
+}
+
+// Export streams all accounts matching opts to enc and closes it. It returns number of exported accounts.
+// Encoder is closed on error too, so accounts exported before it are flushed.
+func Export(ctx context.Context, lister Lister, opts *client.AccountListOptions, enc Encoder) (count int, err error) {
+	defer func() {
+		if closeErr := enc.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	err = lister.ListAll(ctx, opts, func(acc *models.Account) error {
+		count++
+		return enc.Encode(acc)
+	})
+	return count, err
+}
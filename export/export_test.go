@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GB", r.URL.Query().Get("filter[country]"))
+		switch r.URL.Query().Get("page[number]") {
+		case "":
+			fmt.Fprint(w, `{"data":[{"id":"a","attributes":{"country":"GB"}}],"links":{"next":"/v1/organisation/accounts?filter[country]=GB&page[number]=1"}}`)
+		case "1":
+			fmt.Fprint(w, `{"data":[{"id":"b","attributes":{"country":"GB"}}],"links":{}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	c := client.NewClient(nil, u)
+
+	buf := &bytes.Buffer{}
+	enc, err := NewCSVEncoder(buf, []string{"id", "country"})
+	require.Nil(t, err)
+
+	count, err := Export(context.TODO(), c.Account, &client.AccountListOptions{Filter: &client.AccountFilter{Country: "GB"}}, enc)
+	require.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "id,country\na,GB\nb,GB\n", buf.String())
+}
+
+func TestExport_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[number]") == "" {
+			fmt.Fprint(w, `{"data":[{"id":"a","attributes":{"country":"GB"}}],"links":{"next":"/v1/organisation/accounts?page[number]=1"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error_message":"boom"}`)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	c := client.NewClient(nil, u)
+
+	buf := &bytes.Buffer{}
+	enc, err := NewCSVEncoder(buf, []string{"id", "country"})
+	require.Nil(t, err)
+
+	count, err := Export(context.TODO(), c.Account, nil, enc)
+	assert.EqualError(t, err, "code: 500, message: boom")
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "id,country\na,GB\n", buf.String(), "it should flush accounts exported before the error")
+}
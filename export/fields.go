@@ -0,0 +1,92 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// field is a single exported column. Names match JSON API field names, with attributes flattened.
+type field struct {
+	name  string
+	value func(acc *models.Account) interface{}
+}
+
+// allFields lists exportable fields in default column order.
+var allFields = []field{
+	{"id", func(a *models.Account) interface{} { return a.ID }},
+	{"organisation_id", func(a *models.Account) interface{} { return a.OrganisationID }},
+	{"type", func(a *models.Account) interface{} { return a.Type }},
+	{"version", func(a *models.Account) interface{} { return a.Version }},
+	{"country", func(a *models.Account) interface{} { return a.Attributes.Country }},
+	{"base_currency", func(a *models.Account) interface{} { return a.Attributes.BaseCurrency }},
+	{"account_number", func(a *models.Account) interface{} { return a.Attributes.AccountNumber }},
+	{"bank_id", func(a *models.Account) interface{} { return a.Attributes.BankID }},
+	{"bank_id_code", func(a *models.Account) interface{} { return a.Attributes.BankIDCode }},
+	{"bic", func(a *models.Account) interface{} { return a.Attributes.Bic }},
+	{"iban", func(a *models.Account) interface{} { return a.Attributes.Iban }},
+	{"title", func(a *models.Account) interface{} { return a.Attributes.Title }},
+	{"first_name", func(a *models.Account) interface{} { return a.Attributes.FirstName }},
+	{"bank_account_name", func(a *models.Account) interface{} { return a.Attributes.BankAccountName }},
+	{"alternative_bank_account_names", func(a *models.Account) interface{} {
+		if a.Attributes.AlternativeBankAccountNames == nil {
+			return []string{}
+		}
+		return a.Attributes.AlternativeBankAccountNames
+	}},
+	{"account_classification", func(a *models.Account) interface{} { return a.Attributes.AccountClassification }},
+	{"joint_account", func(a *models.Account) interface{} { return a.Attributes.JointAccount }},
+	{"account_matching_opt_out", func(a *models.Account) interface{} { return a.Attributes.AccountMatchingOptOut }},
+	{"secondary_identification", func(a *models.Account) interface{} { return a.Attributes.SecondaryIdentification }},
+}
+
+// FieldNames returns names of all exportable fields in default order.
+func FieldNames() []string {
+	names := make([]string, len(allFields))
+	for i, f := range allFields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// members lists fields which are top level members of accounts rather than attributes. They are always
+// returned by the API and cannot be selected by sparse fieldsets.
+var members = map[string]bool{"id": true, "organisation_id": true, "type": true, "version": true}
+
+// SparseFields returns attributes among given field names, to be requested as sparse fieldset with
+// client.AccountListOptions.Fields. It returns nil, i.e. all attributes are requested, if names has no attributes.
+func SparseFields(names []string) []string {
+	var attributes []string
+	for _, name := range names {
+		if _, ok := fieldByName(name); ok && !members[name] {
+			attributes = append(attributes, name)
+		}
+	}
+	return attributes
+}
+
+// selectFields returns fields with given names in given order, or all fields if names is empty.
+func selectFields(names []string) ([]field, error) {
+	if len(names) == 0 {
+		return allFields, nil
+	}
+
+	selected := make([]field, 0, len(names))
+	for _, name := range names {
+		f, ok := fieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		selected = append(selected, f)
+	}
+	return selected, nil
+}
+
+func fieldByName(name string) (field, bool) {
+	for _, f := range allFields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return field{}, false
+}
@@ -0,0 +1,160 @@
+// Package importer creates accounts in bulk from CSV or JSON sources.
+package importer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Creator creates accounts. It is implemented by client.AccountService.
+type Creator interface {
+	Create(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error)
+}
+
+// Summary counts results of an import by status.
+type Summary map[Status]int
+
+// Importer validates rows and creates accounts with bounded concurrency.
+type Importer struct {
+	creator Creator
+
+	// Concurrency limits number of accounts created at once. Defaults to 1.
+	Concurrency int
+	// Previous holds results of a previous run. Rows which were created then are skipped.
+	Previous map[int]Result
+	// OrganisationID is used for rows which do not specify organisation.
+	OrganisationID string
+}
+
+// New creates Importer which creates accounts using given creator.
+func New(creator Creator) *Importer {
+	return &Importer{creator: creator, Concurrency: 1}
+}
+
+// Import reads all rows from reader, creates valid accounts and writes result of every row to results.
+// Errors of individual rows are reported in results; returned error means reading source or
+// writing results failed, or ctx was cancelled.
+func (im *Importer) Import(ctx context.Context, reader Reader, results *ResultWriter) (Summary, error) {
+	concurrency := im.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		summary  = make(Summary)
+	)
+	record := func(result Result) {
+		err := results.Write(result)
+		mu.Lock()
+		defer mu.Unlock()
+		summary[result.Status]++
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	rows := make(chan job)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range rows {
+				record(im.create(ctx, j))
+			}
+		}()
+	}
+
+	readErr := im.feed(ctx, reader, rows, record)
+	close(rows)
+	wg.Wait()
+
+	if readErr != nil {
+		return summary, readErr
+	}
+	return summary, firstErr
+}
+
+// job is a row to be created. Retried rows reuse ID of the previous attempt.
+type job struct {
+	row     *Row
+	retried bool
+}
+
+// feed sends rows which need to be created to workers and records results of the others.
+func (im *Importer) feed(ctx context.Context, reader Reader, rows chan<- job, record func(Result)) error {
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		prev, ok := im.Previous[row.Line]
+		if ok && (prev.Status == StatusCreated || prev.Status == StatusSkipped) {
+			record(Result{Line: row.Line, ID: prev.ID, Status: StatusSkipped})
+			continue
+		}
+
+		retried := false
+		if row.Err == nil {
+			// Create of a failed row may have succeeded after all, e.g. if response was lost, so it is
+			// retried with the same ID to fail with a conflict rather than create a duplicate.
+			if row.Account.ID == "" && prev.ID != "" {
+				row.Account.ID = prev.ID
+				retried = true
+			}
+			im.prepare(&row.Account)
+			row.Err = Validate(&row.Account)
+		}
+		if row.Err != nil {
+			record(Result{Line: row.Line, ID: row.Account.ID, Status: StatusInvalid, Error: row.Err.Error()})
+			continue
+		}
+
+		select {
+		case rows <- job{row: row, retried: retried}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// prepare fills in defaults for fields missing in the source.
+func (im *Importer) prepare(account *models.Account) {
+	if account.ID == "" {
+		account.ID = uuid.New().String()
+	}
+	if account.OrganisationID == "" {
+		account.OrganisationID = im.OrganisationID
+	}
+	if account.Type == "" {
+		account.Type = "accounts"
+	}
+}
+
+// create creates account of the job. Conflict of retried row means the account was created by
+// the previous attempt, whose response was lost.
+func (im *Importer) create(ctx context.Context, j job) Result {
+	row := j.row
+	created, _, err := im.creator.Create(ctx, &row.Account)
+	var errResp *client.ErrorResponse
+	if j.retried && errors.As(err, &errResp) && errResp.StatusCode == http.StatusConflict {
+		return Result{Line: row.Line, ID: row.Account.ID, Status: StatusCreated}
+	}
+	if err != nil {
+		return Result{Line: row.Line, ID: row.Account.ID, Status: StatusFailed, Error: err.Error()}
+	}
+	return Result{Line: row.Line, ID: created.ID, Status: StatusCreated}
+}
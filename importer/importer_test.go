@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCreator records created accounts and fails for account numbers listed in fail.
+type fakeCreator struct {
+	mu      sync.Mutex
+	created []string
+	ids     []string
+	fail    map[string]bool
+	// lose lists account numbers whose accounts are created, but error is returned as if response was lost.
+	lose     map[string]bool
+	existing map[string]bool
+	active   int
+	peak     int
+}
+
+func (f *fakeCreator) Create(ctx context.Context, account *models.Account) (*models.Account, *client.Response, error) {
+	f.mu.Lock()
+	f.active++
+	if f.active > f.peak {
+		f.peak = f.active
+	}
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.active--
+		f.mu.Unlock()
+	}()
+
+	if f.fail[account.Attributes.AccountNumber] {
+		return nil, nil, errors.New("code: 500, message: boom")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.existing[account.ID] {
+		return nil, nil, &client.ErrorResponse{StatusCode: http.StatusConflict, Message: "Account cannot be created as it violates a duplicate constraint"}
+	}
+	if f.existing == nil {
+		f.existing = make(map[string]bool)
+	}
+	f.existing[account.ID] = true
+	if f.lose[account.Attributes.AccountNumber] {
+		return nil, nil, errors.New("unexpected EOF")
+	}
+	f.created = append(f.created, account.Attributes.AccountNumber)
+	f.ids = append(f.ids, account.ID)
+	return account, nil, nil
+}
+
+const importCSV = `organisation_id,country,account_number
+,GB,1
+,GB,2
+,Great Britain,3
+,GB,4
+`
+
+func TestImporter_Import(t *testing.T) {
+	creator := &fakeCreator{fail: map[string]bool{"2": true}}
+	im := New(creator)
+	im.Concurrency = 2
+	im.OrganisationID = "efab8098-d2e7-47f0-9db3-1c318920f71d"
+
+	reader, err := NewCSVReader(strings.NewReader(importCSV), nil)
+	require.Nil(t, err)
+	buf := &bytes.Buffer{}
+	results, err := NewResultWriter(buf, true)
+	require.Nil(t, err)
+
+	summary, err := im.Import(context.TODO(), reader, results)
+	require.Nil(t, err)
+	assert.Equal(t, Summary{StatusCreated: 2, StatusFailed: 1, StatusInvalid: 1}, summary)
+	assert.ElementsMatch(t, []string{"1", "4"}, creator.created)
+	assert.True(t, creator.peak <= 2)
+
+	previous, err := ReadResults(bytes.NewReader(buf.Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, StatusFailed, previous[2].Status)
+	assert.Equal(t, "code: 500, message: boom", previous[2].Error)
+	assert.NotEmpty(t, previous[2].ID)
+	assert.Equal(t, StatusInvalid, previous[3].Status)
+	assert.Equal(t, "country must be ISO 3166-1 alpha-2 code", previous[3].Error)
+
+	t.Run("it should resume and retry only rows which were not created", func(t *testing.T) {
+		creator.fail = nil
+		creator.created = nil
+		creator.ids = nil
+		im.Previous = previous
+
+		reader, err := NewCSVReader(strings.NewReader(importCSV), nil)
+		require.Nil(t, err)
+		results, err := NewResultWriter(buf, false)
+		require.Nil(t, err)
+
+		summary, err := im.Import(context.TODO(), reader, results)
+		require.Nil(t, err)
+		assert.Equal(t, Summary{StatusCreated: 1, StatusSkipped: 2, StatusInvalid: 1}, summary)
+		assert.Equal(t, []string{"2"}, creator.created)
+		assert.Equal(t, []string{previous[2].ID}, creator.ids)
+	})
+}
+
+func TestImporter_ImportResumeLostResponse(t *testing.T) {
+	creator := &fakeCreator{lose: map[string]bool{"2": true}}
+	im := New(creator)
+	im.OrganisationID = "efab8098-d2e7-47f0-9db3-1c318920f71d"
+
+	buf := &bytes.Buffer{}
+	for _, header := range []bool{true, false} {
+		reader, err := NewCSVReader(strings.NewReader(importCSV), nil)
+		require.Nil(t, err)
+		results, err := NewResultWriter(buf, header)
+		require.Nil(t, err)
+		_, err = im.Import(context.TODO(), reader, results)
+		require.Nil(t, err)
+
+		im.Previous, err = ReadResults(bytes.NewReader(buf.Bytes()))
+		require.Nil(t, err)
+	}
+
+	assert.Equal(t, StatusCreated, im.Previous[2].Status, "it should treat conflict of retried row as created")
+	assert.Equal(t, []string{"1", "4"}, creator.created)
+}
+
+func TestImporter_ImportReadError(t *testing.T) {
+	im := New(&fakeCreator{})
+	results, _ := NewResultWriter(&bytes.Buffer{}, true)
+
+	_, err := im.Import(context.TODO(), NewJSONReader(strings.NewReader(`[{"id": 1}`)), results)
+	assert.EqualError(t, err, "line 1: json: cannot unmarshal number into Go struct field Account.id of type string")
+}
@@ -0,0 +1,179 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Row is a single account read from import source. Err is set when row could not be mapped to an account.
+type Row struct {
+	// Line identifies the row in the source: CSV record number or JSON array index, starting at 1.
+	Line    int
+	Account models.Account
+	Err     error
+}
+
+// Reader reads accounts to import one by one. It returns io.EOF when there are no more rows.
+type Reader interface {
+	Read() (*Row, error)
+}
+
+// Mapping maps source column names to account field names, as they are named in JSON API
+// (e.g. "organisation_id", "bank_id"). Columns which are not mapped are ignored.
+type Mapping map[string]string
+
+// listSeparator separates values of list fields within a single CSV cell.
+const listSeparator = ";"
+
+// fieldSetters set account fields from their string representation.
+var fieldSetters = map[string]func(acc *models.Account, value string) error{
+	"id":                             func(a *models.Account, v string) error { a.ID = v; return nil },
+	"organisation_id":                func(a *models.Account, v string) error { a.OrganisationID = v; return nil },
+	"country":                        func(a *models.Account, v string) error { a.Attributes.Country = v; return nil },
+	"base_currency":                  func(a *models.Account, v string) error { a.Attributes.BaseCurrency = v; return nil },
+	"account_number":                 func(a *models.Account, v string) error { a.Attributes.AccountNumber = v; return nil },
+	"bank_id":                        func(a *models.Account, v string) error { a.Attributes.BankID = v; return nil },
+	"bank_id_code":                   func(a *models.Account, v string) error { a.Attributes.BankIDCode = v; return nil },
+	"bic":                            func(a *models.Account, v string) error { a.Attributes.Bic = v; return nil },
+	"iban":                           func(a *models.Account, v string) error { a.Attributes.Iban = v; return nil },
+	"title":                          func(a *models.Account, v string) error { a.Attributes.Title = v; return nil },
+	"first_name":                     func(a *models.Account, v string) error { a.Attributes.FirstName = v; return nil },
+	"bank_account_name":              func(a *models.Account, v string) error { a.Attributes.BankAccountName = v; return nil },
+	"account_classification":         func(a *models.Account, v string) error { a.Attributes.AccountClassification = v; return nil },
+	"secondary_identification":       func(a *models.Account, v string) error { a.Attributes.SecondaryIdentification = v; return nil },
+	"status":                         func(a *models.Account, v string) error { a.Attributes.Status = models.AccountStatus(v); return nil },
+	"status_reason":                  func(a *models.Account, v string) error { a.Attributes.StatusReason = v; return nil },
+	"alternative_bank_account_names": setAlternativeNames,
+	"joint_account": func(a *models.Account, v string) (err error) {
+		a.Attributes.JointAccount, err = parseBool(v)
+		return err
+	},
+	"account_matching_opt_out": func(a *models.Account, v string) (err error) {
+		a.Attributes.AccountMatchingOptOut, err = parseBool(v)
+		return err
+	},
+}
+
+func setAlternativeNames(a *models.Account, v string) error {
+	a.Attributes.AlternativeBankAccountNames = nil
+	for _, name := range strings.Split(v, listSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			a.Attributes.AlternativeBankAccountNames = append(a.Attributes.AlternativeBankAccountNames, name)
+		}
+	}
+	return nil
+}
+
+func parseBool(v string) (bool, error) {
+	if v == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
+
+// CSVReader reads accounts from CSV with a header row.
+type CSVReader struct {
+	csv     *csv.Reader
+	columns []string
+	line    int
+}
+
+// NewCSVReader creates CSVReader and reads the header. If mapping is nil, column names are expected
+// to match account field names.
+func NewCSVReader(r io.Reader, mapping Mapping) (*CSVReader, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if mapping != nil {
+			name = mapping[name]
+		}
+		if name == "" {
+			continue
+		}
+		if _, ok := fieldSetters[name]; !ok {
+			return nil, fmt.Errorf("unknown account field %q in column %d", name, i+1)
+		}
+		columns[i] = name
+	}
+
+	return &CSVReader{csv: cr, columns: columns}, nil
+}
+
+// Read implements Reader.
+func (r *CSVReader) Read() (*Row, error) {
+	record, err := r.csv.Read()
+	if err == io.EOF {
+		return nil, err
+	}
+	r.line++
+	row := &Row{Line: r.line, Account: models.Account{Type: "accounts"}}
+	if err != nil {
+		row.Err = err
+		return row, nil
+	}
+
+	for i, value := range record {
+		if i >= len(r.columns) || r.columns[i] == "" {
+			continue
+		}
+		if err := fieldSetters[r.columns[i]](&row.Account, strings.TrimSpace(value)); err != nil {
+			row.Err = fmt.Errorf("%s: %w", r.columns[i], err)
+			return row, nil
+		}
+	}
+	return row, nil
+}
+
+// JSONReader reads accounts from JSON array of account objects.
+type JSONReader struct {
+	dec     *json.Decoder
+	started bool
+	line    int
+}
+
+// NewJSONReader creates JSONReader.
+func NewJSONReader(r io.Reader) *JSONReader {
+	return &JSONReader{dec: json.NewDecoder(r)}
+}
+
+// Read implements Reader.
+func (r *JSONReader) Read() (*Row, error) {
+	if !r.started {
+		tok, err := r.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("expected JSON array of accounts, but got %v", tok)
+		}
+		r.started = true
+	}
+
+	if !r.dec.More() {
+		return nil, io.EOF
+	}
+
+	r.line++
+	row := &Row{Line: r.line}
+	if err := r.dec.Decode(&row.Account); err != nil {
+		// decoder cannot recover from syntax errors, so stop reading
+		return nil, fmt.Errorf("line %d: %w", r.line, err)
+	}
+	if row.Account.Type == "" {
+		row.Account.Type = "accounts"
+	}
+	return row, nil
+}
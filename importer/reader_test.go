@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, r Reader) []*Row {
+	var rows []*Row
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows
+		}
+		require.Nil(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	input := "Account ID, Country, Sort code, Names, Joint, Notes\n" +
+		"b8952241-a065-462e-a7d2-6a9c94010f0f, GB, 400302, Jane Doe;J Doe, true, vip\n" +
+		"63c0a226-5b6c-4ef9-a0bb-436dd39d45bb, GB, 400303, , maybe, \n"
+	mapping := Mapping{
+		"Account ID": "id",
+		"Country":    "country",
+		"Sort code":  "bank_id",
+		"Names":      "alternative_bank_account_names",
+		"Joint":      "joint_account",
+	}
+
+	r, err := NewCSVReader(strings.NewReader(input), mapping)
+	require.Nil(t, err)
+	rows := readAll(t, r)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, &Row{
+		Line: 1,
+		Account: models.Account{
+			ID:   "b8952241-a065-462e-a7d2-6a9c94010f0f",
+			Type: "accounts",
+			Attributes: models.AccountAttributes{
+				Country:                     "GB",
+				BankID:                      "400302",
+				AlternativeBankAccountNames: []string{"Jane Doe", "J Doe"},
+				JointAccount:                true,
+			},
+		},
+	}, rows[0])
+	assert.Equal(t, 2, rows[1].Line)
+	assert.EqualError(t, rows[1].Err, `joint_account: strconv.ParseBool: parsing "maybe": invalid syntax`)
+}
+
+func TestNewCSVReader_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenInput    string
+		expectedError string
+	}{
+		{
+			name:          "it should fail on empty input",
+			givenInput:    "",
+			expectedError: "read header: EOF",
+		},
+		{
+			name:          "it should fail on unknown column without mapping",
+			givenInput:    "id,colour\n",
+			expectedError: `unknown account field "colour" in column 2`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewCSVReader(strings.NewReader(test.givenInput), nil)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestJSONReader(t *testing.T) {
+	input := `[
+		{"id": "a", "attributes": {"country": "GB"}},
+		{"id": "b", "type": "accounts", "attributes": {"country": "FR"}}
+	]`
+
+	rows := readAll(t, NewJSONReader(strings.NewReader(input)))
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1, rows[0].Line)
+	assert.Equal(t, "a", rows[0].Account.ID)
+	assert.Equal(t, "accounts", rows[0].Account.Type)
+	assert.Equal(t, "FR", rows[1].Account.Attributes.Country)
+
+	_, err := NewJSONReader(strings.NewReader(`{"id": "a"}`)).Read()
+	assert.EqualError(t, err, "expected JSON array of accounts, but got {")
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Status is outcome of importing a single row.
+type Status string
+
+// Import statuses.
+const (
+	// StatusCreated means account was created.
+	StatusCreated Status = "created"
+	// StatusInvalid means row could not be mapped to an account or the account is not valid.
+	StatusInvalid Status = "invalid"
+	// StatusFailed means API rejected the account or could not be reached.
+	StatusFailed Status = "failed"
+	// StatusSkipped means row was created by a previous run and was not sent again.
+	StatusSkipped Status = "skipped"
+)
+
+var resultsHeader = []string{"line", "id", "status", "error"}
+
+// Result is outcome of importing a single row.
+type Result struct {
+	Line   int
+	ID     string
+	Status Status
+	Error  string
+}
+
+// ResultWriter writes results as CSV with header. It is safe for concurrent use and flushes
+// every result, so results of interrupted import are not lost.
+type ResultWriter struct {
+	mu  sync.Mutex
+	csv *csv.Writer
+}
+
+// NewResultWriter creates ResultWriter. Header is written unless appending to existing results.
+func NewResultWriter(w io.Writer, header bool) (*ResultWriter, error) {
+	rw := &ResultWriter{csv: csv.NewWriter(w)}
+	if header {
+		if err := rw.write(resultsHeader); err != nil {
+			return nil, err
+		}
+	}
+	return rw, nil
+}
+
+// Write writes a single result.
+func (w *ResultWriter) Write(result Result) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.write([]string{strconv.Itoa(result.Line), result.ID, string(result.Status), result.Error})
+}
+
+func (w *ResultWriter) write(record []string) error {
+	if err := w.csv.Write(record); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// ReadResults reads results written by ResultWriter, indexed by line. If a line was imported
+// more than once, the last result wins.
+func ReadResults(r io.Reader) (map[int]Result, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(resultsHeader)
+	results := make(map[int]Result)
+
+	first := true
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			first = false
+			if record[0] == resultsHeader[0] {
+				continue
+			}
+		}
+
+		line, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("malformed line number %q", record[0])
+		}
+		results[line] = Result{Line: line, ID: record[1], Status: Status(record[2]), Error: record[3]}
+	}
+}
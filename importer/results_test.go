@@ -0,0 +1,36 @@
+package importer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_RoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewResultWriter(buf, true)
+	require.Nil(t, err)
+	require.Nil(t, w.Write(Result{Line: 1, ID: "a", Status: StatusFailed, Error: "code: 500, message: boom"}))
+	require.Nil(t, w.Write(Result{Line: 2, ID: "b", Status: StatusCreated}))
+
+	// resumed run appends to the same file without header
+	w, err = NewResultWriter(buf, false)
+	require.Nil(t, err)
+	require.Nil(t, w.Write(Result{Line: 1, ID: "a", Status: StatusCreated}))
+
+	assert.Equal(t, "line,id,status,error\n1,a,failed,\"code: 500, message: boom\"\n2,b,created,\n1,a,created,\n", buf.String())
+
+	results, err := ReadResults(bytes.NewReader(buf.Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, map[int]Result{
+		1: {Line: 1, ID: "a", Status: StatusCreated},
+		2: {Line: 2, ID: "b", Status: StatusCreated},
+	}, results)
+}
+
+func TestReadResults_Malformed(t *testing.T) {
+	_, err := ReadResults(bytes.NewReader([]byte("line,id,status,error\nx,a,created,\n")))
+	assert.EqualError(t, err, `malformed line number "x"`)
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+var (
+	countryPattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	bicPattern      = regexp.MustCompile(`^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+	ibanPattern     = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+)
+
+// ValidationError lists all problems found in a single account.
+type ValidationError []string
+
+// Error is required to be implemented to meet error interface
+func (e ValidationError) Error() string {
+	return strings.Join(e, "; ")
+}
+
+// Validate checks that account has all fields required by the API and that they are well formed.
+func Validate(account *models.Account) error {
+	var errs ValidationError
+	if _, err := uuid.Parse(account.ID); err != nil {
+		errs = append(errs, "id must be a valid UUID")
+	}
+	if _, err := uuid.Parse(account.OrganisationID); err != nil {
+		errs = append(errs, "organisation_id must be a valid UUID")
+	}
+
+	attrs := account.Attributes
+	if !countryPattern.MatchString(attrs.Country) {
+		errs = append(errs, "country must be ISO 3166-1 alpha-2 code")
+	}
+	if attrs.BaseCurrency != "" && !currencyPattern.MatchString(attrs.BaseCurrency) {
+		errs = append(errs, "base_currency must be ISO 4217 code")
+	}
+	if attrs.Bic != "" && !bicPattern.MatchString(attrs.Bic) {
+		errs = append(errs, "bic must be 8 or 11 characters long SWIFT code")
+	}
+	if attrs.Iban != "" && !ibanPattern.MatchString(attrs.Iban) {
+		errs = append(errs, "iban is malformed")
+	}
+	switch attrs.AccountClassification {
+	case "", "Personal", "Business":
+	default:
+		errs = append(errs, "account_classification must be Personal or Business")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func validAccount() models.Account {
+	return models.Account{
+		ID:             "b8952241-a065-462e-a7d2-6a9c94010f0f",
+		OrganisationID: "efab8098-d2e7-47f0-9db3-1c318920f71d",
+		Type:           "accounts",
+		Attributes: models.AccountAttributes{
+			Country:      "GB",
+			BaseCurrency: "GBP",
+			Bic:          "NWBKGB42",
+			Iban:         "GB28NWBK40030212764204",
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenModify   func(acc *models.Account)
+		expectedError string
+	}{
+		{
+			name:        "it should accept valid account",
+			givenModify: func(acc *models.Account) {},
+		},
+		{
+			name: "it should require valid ids",
+			givenModify: func(acc *models.Account) {
+				acc.ID = "1"
+				acc.OrganisationID = ""
+			},
+			expectedError: "id must be a valid UUID; organisation_id must be a valid UUID",
+		},
+		{
+			name: "it should require country code",
+			givenModify: func(acc *models.Account) {
+				acc.Attributes.Country = "United Kingdom"
+			},
+			expectedError: "country must be ISO 3166-1 alpha-2 code",
+		},
+		{
+			name: "it should reject malformed optional attributes",
+			givenModify: func(acc *models.Account) {
+				acc.Attributes.BaseCurrency = "pounds"
+				acc.Attributes.Bic = "NWBK"
+				acc.Attributes.Iban = "GB28"
+				acc.Attributes.AccountClassification = "Private"
+			},
+			expectedError: "base_currency must be ISO 4217 code; bic must be 8 or 11 characters long SWIFT code; " +
+				"iban is malformed; account_classification must be Personal or Business",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			acc := validAccount()
+			test.givenModify(&acc)
+			err := Validate(&acc)
+			if test.expectedError == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
@@ -1,30 +1,84 @@
 package models
 
+// AccountStatus is status of an account.
+type AccountStatus string
+
+// Account statuses. Accounts are pending until they are confirmed or fail. Confirmed accounts can be closed
+// and reopened, or switched to another bank under Current Account Switch Service (CASS).
+const (
+	AccountPending   AccountStatus = "pending"
+	AccountConfirmed AccountStatus = "confirmed"
+	AccountFailed    AccountStatus = "failed"
+	AccountClosed    AccountStatus = "closed"
+	AccountSwitched  AccountStatus = "switched"
+)
+
+// accountTransitions lists statuses each status can be changed to, e.g. by closing account. Pending accounts
+// are confirmed or failed by the API only, so only closed accounts can be changed to confirmed.
+var accountTransitions = map[AccountStatus][]AccountStatus{
+	AccountConfirmed: {AccountClosed, AccountSwitched},
+	AccountClosed:    {AccountConfirmed},
+}
+
+// Effective returns s, or AccountConfirmed if status is not set, as accounts without status are open.
+func (s AccountStatus) Effective() AccountStatus {
+	if s == "" {
+		return AccountConfirmed
+	}
+	return s
+}
+
+// CanTransition reports whether account in status s can change to status next.
+func (s AccountStatus) CanTransition(next AccountStatus) bool {
+	return canTransition(accountTransitions, s.Effective(), next)
+}
+
 // Account represents a bank account that is registered with Form3.
 // It is used to validate and allocate inbound payments.
 type Account struct {
-	Attributes     AccountAttributes `json:"attributes"`
-	ID             string            `json:"id"`
-	OrganisationID string            `json:"organisation_id"`
-	Type           string            `json:"type"`
-	Version        int               `json:"version"`
+	Attributes     AccountAttributes     `json:"attributes"`
+	ID             string                `json:"id"`
+	OrganisationID string                `json:"organisation_id"`
+	Type           string                `json:"type"`
+	Version        int                   `json:"version"`
+	Relationships  *AccountRelationships `json:"relationships,omitempty"`
+}
+
+// AccountRelationships represents resources related to an account.
+type AccountRelationships struct {
+	// MasterAccount is account which holds funds of this account, e.g. for virtual accounts.
+	MasterAccount *Relationship `json:"master_account,omitempty"`
+	Organisation  *Relationship `json:"organisation,omitempty"`
 }
 
 // AccountAttributes represents account attributes.
 type AccountAttributes struct {
-	Country                     string   `json:"country"`
-	BaseCurrency                string   `json:"base_currency,omitempty"`
-	AccountNumber               string   `json:"account_number,omitempty"`
-	BankID                      string   `json:"bank_id,omitempty"`
-	BankIDCode                  string   `json:"bank_id_code,omitempty"`
-	Bic                         string   `json:"bic,omitempty"`
-	Iban                        string   `json:"iban,omitempty"`
-	Title                       string   `json:"title,omitempty"`
-	FirstName                   string   `json:"first_name,omitempty"`
-	BankAccountName             string   `json:"bank_account_name,omitempty"`
-	AlternativeBankAccountNames []string `json:"alternative_bank_account_names,omitempty"`
-	AccountClassification       string   `json:"account_classification,omitempty"`
-	JointAccount                bool     `json:"joint_account,omitempty"`
-	AccountMatchingOptOut       bool     `json:"account_matching_opt_out,omitempty"`
-	SecondaryIdentification     string   `json:"secondary_identification,omitempty"`
+	Country                     string        `json:"country"`
+	BaseCurrency                string        `json:"base_currency,omitempty"`
+	AccountNumber               string        `json:"account_number,omitempty"`
+	BankID                      string        `json:"bank_id,omitempty"`
+	BankIDCode                  string        `json:"bank_id_code,omitempty"`
+	Bic                         string        `json:"bic,omitempty"`
+	Iban                        string        `json:"iban,omitempty"`
+	Title                       string        `json:"title,omitempty"`
+	FirstName                   string        `json:"first_name,omitempty"`
+	BankAccountName             string        `json:"bank_account_name,omitempty"`
+	AlternativeBankAccountNames []string      `json:"alternative_bank_account_names,omitempty"`
+	AccountClassification       string        `json:"account_classification,omitempty"`
+	JointAccount                bool          `json:"joint_account,omitempty"`
+	AccountMatchingOptOut       bool          `json:"account_matching_opt_out,omitempty"`
+	SecondaryIdentification     string        `json:"secondary_identification,omitempty"`
+	Status                      AccountStatus `json:"status,omitempty"`
+	StatusReason                string        `json:"status_reason,omitempty"`
+	// SwitchedAccount is set for accounts switched to another bank.
+	SwitchedAccount *SwitchedAccount `json:"switched_account_details,omitempty"`
+}
+
+// SwitchedAccount holds details of the account which replaced a switched account.
+type SwitchedAccount struct {
+	AccountNumber string `json:"account_number"`
+	BankID        string `json:"bank_id"`
+	BankIDCode    string `json:"bank_id_code"`
+	// SwitchedDate is date of the switch, formatted as YYYY-MM-DD.
+	SwitchedDate string `json:"switched_date,omitempty"`
 }
@@ -0,0 +1,63 @@
+package models
+
+// Direct debit schemes of mandates.
+const (
+	SchemeBacs   = "bacs"
+	SchemeSepaDD = "sepadd"
+)
+
+// MandateStatus is status of a direct debit mandate.
+type MandateStatus string
+
+// Mandate statuses. Mandates are pending until the debtor bank activates or rejects them,
+// and pending or active mandates can be cancelled.
+const (
+	MandatePending   MandateStatus = "pending"
+	MandateActive    MandateStatus = "active"
+	MandateFailed    MandateStatus = "failed"
+	MandateCancelled MandateStatus = "cancelled"
+)
+
+// mandateTransitions lists statuses each status can change to.
+var mandateTransitions = map[MandateStatus][]MandateStatus{
+	MandatePending: {MandateActive, MandateFailed, MandateCancelled},
+	MandateActive:  {MandateCancelled},
+}
+
+// Terminal reports whether status is final.
+func (s MandateStatus) Terminal() bool {
+	return s == MandateFailed || s == MandateCancelled
+}
+
+// CanTransition reports whether mandate in status s can change to status next.
+func (s MandateStatus) CanTransition(next MandateStatus) bool {
+	return canTransition(mandateTransitions, s, next)
+}
+
+// Mandate represents a direct debit mandate authorising creditor to collect payments from debtor account.
+type Mandate struct {
+	Attributes     MandateAttributes    `json:"attributes"`
+	ID             string               `json:"id"`
+	OrganisationID string               `json:"organisation_id,omitempty"`
+	Type           string               `json:"type"`
+	Version        int                  `json:"version"`
+	Relationships  MandateRelationships `json:"relationships"`
+}
+
+// MandateAttributes represents mandate attributes.
+type MandateAttributes struct {
+	// Scheme is SchemeBacs or SchemeSepaDD.
+	Scheme    string `json:"scheme,omitempty"`
+	Reference string `json:"reference,omitempty"`
+	// SequenceType is "RCUR" for recurring or "OOFF" for one-off SEPA collections.
+	SequenceType  string        `json:"sequence_type,omitempty"`
+	SignatureDate string        `json:"signature_date,omitempty"`
+	Status        MandateStatus `json:"status,omitempty"`
+	StatusReason  string        `json:"status_reason,omitempty"`
+}
+
+// MandateRelationships links mandate to debtor and creditor accounts.
+type MandateRelationships struct {
+	DebtorAccount   *Relationship `json:"debtor_account,omitempty"`
+	CreditorAccount *Relationship `json:"creditor_account,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// NameMatch is result of comparing a payee name with name of the account holder.
+type NameMatch string
+
+// Name match results.
+const (
+	// NameFullMatch means name matches name of the account holder.
+	NameFullMatch NameMatch = "full_match"
+	// NameCloseMatch means name is similar, SuggestedName holds name of the account holder.
+	NameCloseMatch NameMatch = "close_match"
+	// NameNoMatch means name does not match, or account was not found.
+	NameNoMatch NameMatch = "no_match"
+	// NameOptedOut means account holder opted out of name matching.
+	NameOptedOut NameMatch = "opted_out"
+)
+
+// NameVerification represents Confirmation of Payee request checking that name belongs to the identified account.
+// Request attributes are set by the caller, result attributes are filled in by the API.
+type NameVerification struct {
+	Attributes     NameVerificationAttributes `json:"attributes"`
+	ID             string                     `json:"id"`
+	OrganisationID string                     `json:"organisation_id"`
+	Type           string                     `json:"type"`
+}
+
+// NameVerificationAttributes represents name verification attributes.
+type NameVerificationAttributes struct {
+	// Name is the payee name to verify.
+	Name                    string `json:"name"`
+	AccountNumber           string `json:"account_number"`
+	BankID                  string `json:"bank_id"`
+	BankIDCode              string `json:"bank_id_code,omitempty"`
+	SecondaryIdentification string `json:"secondary_identification,omitempty"`
+	// AccountClassification is "Personal" or "Business".
+	AccountClassification string `json:"account_classification,omitempty"`
+
+	Result NameVerificationResult `json:"result"`
+}
+
+// NameVerificationResult is outcome of name verification.
+type NameVerificationResult struct {
+	Match NameMatch `json:"match,omitempty"`
+	// SuggestedName is name of the account holder, set on close match.
+	SuggestedName string `json:"suggested_name,omitempty"`
+	// ReasonCode explains the result, e.g. "AC01" when account does not exist.
+	ReasonCode string `json:"reason_code,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// Organisation represents an organisation unit. Accounts belong to organisations, and organisations
+// can be nested under a parent organisation.
+type Organisation struct {
+	Attributes OrganisationAttributes `json:"attributes"`
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	Version    int                    `json:"version"`
+}
+
+// OrganisationAttributes represents organisation attributes.
+type OrganisationAttributes struct {
+	Name string `json:"name"`
+	// ParentID is ID of parent organisation, empty for top level organisations.
+	ParentID string `json:"parent_id,omitempty"`
+}
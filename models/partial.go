@@ -0,0 +1,47 @@
+package models
+
+import "encoding/json"
+
+// FieldSet holds names of fields present in a decoded resource.
+type FieldSet map[string]bool
+
+// Has reports whether field with given name was present.
+func (f FieldSet) Has(name string) bool {
+	return f[name]
+}
+
+// PartialAccount is an account which may hold only some of its attributes, e.g. when sparse fieldset
+// was requested. Missing attributes are left as zero values and Fields tells which ones were present.
+type PartialAccount struct {
+	Account
+	// Fields holds JSON names of top level members and attributes, flattened, e.g. "id" and "bank_id".
+	Fields FieldSet `json:"-"`
+}
+
+// UnmarshalJSON decodes account and records which of its fields are present.
+func (p *PartialAccount) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	var attributes map[string]json.RawMessage
+	if raw, ok := members["attributes"]; ok {
+		if err := json.Unmarshal(raw, &attributes); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(data, &p.Account); err != nil {
+		return err
+	}
+
+	p.Fields = make(FieldSet, len(members)+len(attributes))
+	for name := range members {
+		if name != "attributes" {
+			p.Fields[name] = true
+		}
+	}
+	for name := range attributes {
+		p.Fields[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package models
+
+// Payment represents a payment of the transaction API.
+type Payment struct {
+	Attributes     PaymentAttributes `json:"attributes"`
+	ID             string            `json:"id"`
+	OrganisationID string            `json:"organisation_id"`
+	Type           string            `json:"type"`
+	Version        int               `json:"version"`
+}
+
+// PaymentAttributes represents payment attributes.
+type PaymentAttributes struct {
+	// Amount is a decimal amount, e.g. "100.21", kept as a string to avoid rounding.
+	Amount               string `json:"amount"`
+	Currency             string `json:"currency"`
+	BeneficiaryParty     Party  `json:"beneficiary_party"`
+	DebtorParty          Party  `json:"debtor_party"`
+	PaymentScheme        string `json:"payment_scheme"`
+	PaymentType          string `json:"payment_type,omitempty"`
+	SchemePaymentType    string `json:"scheme_payment_type,omitempty"`
+	SchemePaymentSubType string `json:"scheme_payment_sub_type,omitempty"`
+	ProcessingDate       string `json:"processing_date,omitempty"`
+	Reference            string `json:"reference,omitempty"`
+	EndToEndReference    string `json:"end_to_end_reference,omitempty"`
+	NumericReference     string `json:"numeric_reference,omitempty"`
+	PaymentPurpose       string `json:"payment_purpose,omitempty"`
+}
+
+// Party is a beneficiary or debtor of a payment, identified by the account it is paid to or from.
+type Party struct {
+	AccountName       string      `json:"account_name,omitempty"`
+	AccountNumber     string      `json:"account_number"`
+	AccountNumberCode string      `json:"account_number_code,omitempty"`
+	AccountWith       AccountWith `json:"account_with"`
+	Name              string      `json:"name,omitempty"`
+}
+
+// AccountWith identifies bank holding account of a party.
+type AccountWith struct {
+	BankID     string `json:"bank_id"`
+	BankIDCode string `json:"bank_id_code"`
+}
+
+// NewParty returns payment party referencing given account.
+func NewParty(account *Account) Party {
+	attrs := account.Attributes
+	party := Party{
+		AccountName:       attrs.BankAccountName,
+		AccountNumber:     attrs.AccountNumber,
+		AccountNumberCode: "BBAN",
+		AccountWith:       AccountWith{BankID: attrs.BankID, BankIDCode: attrs.BankIDCode},
+	}
+	if attrs.AccountNumber == "" && attrs.Iban != "" {
+		party.AccountNumber = attrs.Iban
+		party.AccountNumberCode = "IBAN"
+	}
+	return party
+}
+
+// SubmissionStatus is status of payment submission in the payment scheme.
+type SubmissionStatus string
+
+// Submission statuses. Submissions are accepted, then released to the scheme and either confirmed
+// as delivered or failed.
+const (
+	SubmissionAccepted          SubmissionStatus = "accepted"
+	SubmissionReleased          SubmissionStatus = "released"
+	SubmissionDeliveryConfirmed SubmissionStatus = "delivery_confirmed"
+	SubmissionFailed            SubmissionStatus = "failed"
+)
+
+// Terminal reports whether status is final.
+func (s SubmissionStatus) Terminal() bool {
+	return s == SubmissionDeliveryConfirmed || s == SubmissionFailed
+}
+
+// PaymentSubmission represents submission of a payment to the payment scheme.
+type PaymentSubmission struct {
+	Attributes     PaymentSubmissionAttributes `json:"attributes"`
+	ID             string                      `json:"id"`
+	OrganisationID string                      `json:"organisation_id"`
+	Type           string                      `json:"type"`
+	Version        int                         `json:"version"`
+}
+
+// PaymentSubmissionAttributes represents payment submission attributes.
+type PaymentSubmissionAttributes struct {
+	Status             SubmissionStatus `json:"status,omitempty"`
+	StatusReason       string           `json:"status_reason,omitempty"`
+	SchemeStatusCode   string           `json:"scheme_status_code,omitempty"`
+	SubmissionDateTime string           `json:"submission_datetime,omitempty"`
+}
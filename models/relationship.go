@@ -0,0 +1,25 @@
+package models
+
+// ResourceIdentifier identifies a JSON:API resource by its type and ID.
+type ResourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Relationship is a JSON:API to-one relationship.
+type Relationship struct {
+	Data *ResourceIdentifier `json:"data"`
+}
+
+// NewRelationship returns relationship to resource of given type and ID.
+func NewRelationship(resourceType, id string) *Relationship {
+	return &Relationship{Data: &ResourceIdentifier{ID: id, Type: resourceType}}
+}
+
+// ID returns ID of related resource, or empty string if there is none.
+func (r *Relationship) ID() string {
+	if r == nil || r.Data == nil {
+		return ""
+	}
+	return r.Data.ID
+}
@@ -0,0 +1,30 @@
+package models
+
+// Callback transports of subscriptions.
+const (
+	// TransportHTTP delivers notifications as HTTP POST requests to callback URI.
+	TransportHTTP = "http"
+	// TransportQueue delivers notifications to the message queue identified by callback URI.
+	TransportQueue = "queue"
+)
+
+// Subscription represents subscription to notifications about records of given type.
+type Subscription struct {
+	Attributes     SubscriptionAttributes `json:"attributes"`
+	ID             string                 `json:"id"`
+	OrganisationID string                 `json:"organisation_id"`
+	Type           string                 `json:"type"`
+	Version        int                    `json:"version"`
+}
+
+// SubscriptionAttributes represents subscription attributes.
+type SubscriptionAttributes struct {
+	CallbackURI string `json:"callback_uri"`
+	// CallbackTransport is TransportHTTP or TransportQueue.
+	CallbackTransport string `json:"callback_transport"`
+	// RecordType is type of records notifications are sent for, e.g. "accounts" or "payments".
+	RecordType string `json:"record_type"`
+	// EventType limits notifications to a single event type, e.g. "created". Empty means all event types.
+	EventType   string `json:"event_type,omitempty"`
+	Deactivated bool   `json:"deactivated,omitempty"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// canTransition reports whether status from can change to status to. Table lists statuses each status can change to.
+func canTransition[S comparable](table map[S][]S, from, to S) bool {
+	for _, status := range table[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
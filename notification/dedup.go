@@ -0,0 +1,67 @@
+package notification
+
+import "sync"
+
+// Deduplicator remembers processed notification IDs, so redelivered notifications are not dispatched twice.
+type Deduplicator interface {
+	// Claim records notification with given ID as processed and reports whether it was not recorded
+	// before. Concurrent claims of the same ID must succeed only once.
+	Claim(id string) bool
+	// Release forgets notification with given ID, so it is processed again when redelivered,
+	// e.g. because processing of claimed notification failed.
+	Release(id string)
+}
+
+// MemoryDeduplicator keeps a bounded number of the most recently processed IDs in memory.
+type MemoryDeduplicator struct {
+	mu    sync.Mutex
+	ids   map[string]int
+	order []string
+	next  int
+}
+
+// NewMemoryDeduplicator creates a deduplicator remembering up to size IDs.
+func NewMemoryDeduplicator(size int) *MemoryDeduplicator {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryDeduplicator{
+		ids:   make(map[string]int, size),
+		order: make([]string, size),
+	}
+}
+
+// Seen reports whether notification with given ID was already processed.
+func (d *MemoryDeduplicator) Seen(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.ids[id]
+	return ok
+}
+
+// Claim implements Deduplicator. When full, the oldest ID is forgotten.
+func (d *MemoryDeduplicator) Claim(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.ids[id]; ok {
+		return false
+	}
+
+	if old := d.order[d.next]; old != "" {
+		delete(d.ids, old)
+	}
+	d.order[d.next] = id
+	d.ids[id] = d.next
+	d.next = (d.next + 1) % len(d.order)
+	return true
+}
+
+// Release implements Deduplicator.
+func (d *MemoryDeduplicator) Release(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i, ok := d.ids[id]; ok {
+		d.order[i] = ""
+		delete(d.ids, id)
+	}
+}
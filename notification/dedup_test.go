@@ -0,0 +1,52 @@
+package notification
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryDeduplicator(t *testing.T) {
+	d := NewMemoryDeduplicator(2)
+	assert.False(t, d.Seen("a"))
+
+	assert.True(t, d.Claim("a"))
+	assert.True(t, d.Claim("b"))
+	assert.False(t, d.Claim("b"), "it should not claim the same id twice")
+	assert.True(t, d.Seen("a"))
+	assert.True(t, d.Seen("b"))
+
+	assert.True(t, d.Claim("c"))
+	assert.False(t, d.Seen("a"), "it should forget the oldest id when full")
+	assert.True(t, d.Seen("b"))
+	assert.True(t, d.Seen("c"))
+
+	d.Release("b")
+	assert.False(t, d.Seen("b"))
+	assert.True(t, d.Claim("b"), "it should claim released id again")
+	assert.True(t, d.Claim("d"))
+	assert.True(t, d.Seen("b"), "it should not forget reclaimed id by its released slot")
+}
+
+func TestMemoryDeduplicator_ConcurrentClaim(t *testing.T) {
+	d := NewMemoryDeduplicator(10)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		claimed int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if d.Claim("a") {
+				mu.Lock()
+				claimed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, claimed)
+}
@@ -0,0 +1,186 @@
+// Package notification receives Form3 subscription notifications delivered as HTTP callbacks.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Event types of notifications.
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// RecordTypeAccounts is record type of notifications about accounts.
+const RecordTypeAccounts = "accounts"
+
+// maxBodySize limits size of accepted notification body.
+const maxBodySize = 1 << 20
+
+// Envelope is a notification delivered by subscription. Data holds the resource the event is about.
+type Envelope struct {
+	ID             string          `json:"id"`
+	OrganisationID string          `json:"organisation_id"`
+	EventType      string          `json:"event_type"`
+	RecordType     string          `json:"record_type"`
+	Version        int             `json:"version"`
+	CreatedOn      time.Time       `json:"created_on"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// HandlerFunc handles a single notification. Returned error makes the sender retry delivery.
+type HandlerFunc func(ctx context.Context, envelope *Envelope) error
+
+// AccountHandlerFunc handles a notification about an account.
+type AccountHandlerFunc func(ctx context.Context, envelope *Envelope, account *models.Account) error
+
+// Handler is http.Handler which verifies, deduplicates and dispatches notifications to registered handlers.
+// Notifications without matching handler are acknowledged and dropped.
+type Handler struct {
+	verifier     Verifier
+	deduplicator Deduplicator
+
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+}
+
+// NewHandler creates notification handler. If verifier is nil, signatures are not checked.
+// If deduplicator is nil, every delivery is dispatched.
+func NewHandler(verifier Verifier, deduplicator Deduplicator) *Handler {
+	return &Handler{
+		verifier:     verifier,
+		deduplicator: deduplicator,
+		handlers:     make(map[string][]HandlerFunc),
+	}
+}
+
+// Handle registers fn for notifications of given record and event type.
+// Empty event type matches all event types of the record type.
+func (h *Handler) Handle(recordType, eventType string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := handlerKey(recordType, eventType)
+	h.handlers[key] = append(h.handlers[key], fn)
+}
+
+// HandleAccount registers fn for account notifications of given event type, decoding the account from
+// notification data. Empty event type matches all event types.
+func (h *Handler) HandleAccount(eventType string, fn AccountHandlerFunc) {
+	h.Handle(RecordTypeAccounts, eventType, func(ctx context.Context, envelope *Envelope) error {
+		account := &models.Account{}
+		if err := json.Unmarshal(envelope.Data, account); err != nil {
+			return fmt.Errorf("decode account: %w", err)
+		}
+		return fn(ctx, envelope, account)
+	})
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	if h.verifier != nil {
+		if err := h.verifier.Verify(r, body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	envelope, err := Parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.deduplicator != nil && !h.deduplicator.Claim(envelope.ID) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), envelope); err != nil {
+		if h.deduplicator != nil {
+			h.deduplicator.Release(envelope.ID)
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Dispatch passes envelope to all handlers registered for its record and event type.
+// It stops at the first handler error.
+func (h *Handler) Dispatch(ctx context.Context, envelope *Envelope) error {
+	h.mu.RLock()
+	handlers := append([]HandlerFunc{}, h.handlers[handlerKey(envelope.RecordType, envelope.EventType)]...)
+	handlers = append(handlers, h.handlers[handlerKey(envelope.RecordType, "")]...)
+	h.mu.RUnlock()
+
+	for _, fn := range handlers {
+		if err := fn(ctx, envelope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse decodes notification envelope and checks that required fields are present.
+func Parse(body []byte) (*Envelope, error) {
+	envelope := &Envelope{}
+	if err := json.Unmarshal(body, envelope); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case envelope.ID == "":
+		return nil, errors.New("notification id is missing")
+	case envelope.RecordType == "":
+		return nil, errors.New("notification record type is missing")
+	case envelope.EventType == "":
+		return nil, errors.New("notification event type is missing")
+	}
+	return envelope, nil
+}
+
+func handlerKey(recordType, eventType string) string {
+	return recordType + "/" + eventType
+}
+
+// NewRequest builds notification delivery request for given envelope. If secret is not nil,
+// the body is signed the same way HMACVerifier expects.
+func NewRequest(url string, envelope *Envelope, secret []byte) (*http.Request, error) {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != nil {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+	return req, nil
+}
@@ -0,0 +1,238 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("secret")
+
+func testAccount() *models.Account {
+	return &models.Account{
+		ID:             "b8952241-a065-462e-a7d2-6a9c94010f0f",
+		OrganisationID: "efab8098-d2e7-47f0-9db3-1c318920f71d",
+		Type:           "accounts",
+		Version:        1,
+		Attributes:     models.AccountAttributes{Country: "GB"},
+	}
+}
+
+// newAccountEnvelope builds notification about given account.
+func newAccountEnvelope(eventType string, account *models.Account) (*Envelope, error) {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		ID:             uuid.New().String(),
+		OrganisationID: account.OrganisationID,
+		EventType:      eventType,
+		RecordType:     RecordTypeAccounts,
+		Version:        account.Version,
+		Data:           data,
+	}, nil
+}
+
+// post delivers envelope to handler in process and returns recorded response.
+func post(handler http.Handler, envelope *Envelope, secret []byte) (*httptest.ResponseRecorder, error) {
+	req, err := NewRequest("/", envelope, secret)
+	if err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder, nil
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenEventType     string
+		givenSecret        []byte
+		givenHandlerError  error
+		expectedStatusCode int
+		expectedDispatched bool
+	}{
+		{
+			name:               "it should dispatch signed account notification",
+			givenEventType:     EventCreated,
+			givenSecret:        testSecret,
+			expectedStatusCode: http.StatusNoContent,
+			expectedDispatched: true,
+		},
+		{
+			name:               "it should reject notification with invalid signature",
+			givenEventType:     EventCreated,
+			givenSecret:        []byte("other-secret"),
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "it should reject unsigned notification",
+			givenEventType:     EventCreated,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "it should acknowledge notification without registered handler",
+			givenEventType:     EventDeleted,
+			givenSecret:        testSecret,
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "it should fail when handler returns an error so delivery is retried",
+			givenEventType:     EventCreated,
+			givenSecret:        testSecret,
+			givenHandlerError:  errors.New("handler failed"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedDispatched: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := NewHandler(&HMACVerifier{Secret: testSecret}, nil)
+			var dispatched *models.Account
+			h.HandleAccount(EventCreated, func(ctx context.Context, envelope *Envelope, account *models.Account) error {
+				dispatched = account
+				return test.givenHandlerError
+			})
+
+			envelope, err := newAccountEnvelope(test.givenEventType, testAccount())
+			require.Nil(t, err)
+			resp, err := post(h, envelope, test.givenSecret)
+			require.Nil(t, err)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			if test.expectedDispatched {
+				assert.Equal(t, testAccount(), dispatched)
+			} else {
+				assert.Nil(t, dispatched)
+			}
+		})
+	}
+}
+
+func TestHandler_ServeHTTPMalformed(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenMethod        string
+		givenBody          string
+		expectedStatusCode int
+	}{
+		{
+			name:               "it should reject non POST requests",
+			givenMethod:        http.MethodGet,
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:               "it should reject malformed body",
+			givenMethod:        http.MethodPost,
+			givenBody:          "not-a-json",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "it should reject notification without id",
+			givenMethod:        http.MethodPost,
+			givenBody:          `{"event_type":"created","record_type":"accounts"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.givenMethod, "/", strings.NewReader(test.givenBody))
+			resp := httptest.NewRecorder()
+			NewHandler(nil, nil).ServeHTTP(resp, req)
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+		})
+	}
+}
+
+func TestHandler_Deduplicate(t *testing.T) {
+	h := NewHandler(nil, NewMemoryDeduplicator(10))
+	calls := 0
+	fail := true
+	h.HandleAccount("", func(ctx context.Context, envelope *Envelope, account *models.Account) error {
+		calls++
+		if fail {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	envelope, err := newAccountEnvelope(EventUpdated, testAccount())
+	require.Nil(t, err)
+
+	resp, _ := post(h, envelope, nil)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	fail = false
+	resp, _ = post(h, envelope, nil)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp, _ = post(h, envelope, nil)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, 2, calls, "it should redeliver failed notification, but skip processed one")
+}
+
+func TestHandler_DeduplicateConcurrent(t *testing.T) {
+	h := NewHandler(nil, NewMemoryDeduplicator(10))
+	var calls int32
+	release := make(chan struct{})
+	h.HandleAccount("", func(ctx context.Context, envelope *Envelope, account *models.Account) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	})
+
+	envelope, err := newAccountEnvelope(EventUpdated, testAccount())
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, _ := post(h, envelope, nil)
+			codes <- resp.Code
+		}()
+	}
+	assert.Equal(t, http.StatusNoContent, <-codes, "it should acknowledge redelivery while the first one is processed")
+	close(release)
+	wg.Wait()
+	assert.Equal(t, http.StatusNoContent, <-codes)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "it should dispatch concurrent redeliveries once")
+}
+
+func TestHandler_ServeHTTPOverNetwork(t *testing.T) {
+	h := NewHandler(&HMACVerifier{Secret: testSecret}, nil)
+	received := make(chan string, 1)
+	h.Handle(RecordTypeAccounts, EventCreated, func(ctx context.Context, envelope *Envelope) error {
+		received <- envelope.ID
+		return nil
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	envelope, err := newAccountEnvelope(EventCreated, testAccount())
+	require.Nil(t, err)
+	req, err := NewRequest(server.URL, envelope, testSecret)
+	require.Nil(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, envelope.ID, <-received)
+}
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// SignatureHeader is header holding signature of notification body.
+const SignatureHeader = "X-Signature"
+
+const signaturePrefix = "sha256="
+
+// ErrInvalidSignature is returned when notification signature is missing or does not match the body.
+var ErrInvalidSignature = errors.New("invalid notification signature")
+
+// Verifier checks authenticity of delivered notification.
+type Verifier interface {
+	Verify(r *http.Request, body []byte) error
+}
+
+// HMACVerifier verifies HMAC-SHA256 signature of the body sent in SignatureHeader as "sha256=<hex>".
+type HMACVerifier struct {
+	Secret []byte
+}
+
+// Verify implements Verifier.
+func (v *HMACVerifier) Verify(r *http.Request, body []byte) error {
+	header := r.Header.Get(SignatureHeader)
+	if !strings.HasPrefix(header, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	given, err := hex.DecodeString(strings.TrimPrefix(header, signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal(given, signature(v.Secret, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns value of SignatureHeader for given body.
+func Sign(secret, body []byte) string {
+	return signaturePrefix + hex.EncodeToString(signature(secret, body))
+}
+
+func signature(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
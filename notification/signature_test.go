@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMACVerifier_Verify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	tests := []struct {
+		name          string
+		givenHeader   string
+		expectedError error
+	}{
+		{
+			name:        "it should accept valid signature",
+			givenHeader: Sign(testSecret, body),
+		},
+		{
+			name:          "it should reject missing signature",
+			expectedError: ErrInvalidSignature,
+		},
+		{
+			name:          "it should reject signature without prefix",
+			givenHeader:   "abcdef",
+			expectedError: ErrInvalidSignature,
+		},
+		{
+			name:          "it should reject signature which is not hex encoded",
+			givenHeader:   "sha256=zz",
+			expectedError: ErrInvalidSignature,
+		},
+		{
+			name:          "it should reject signature of other body",
+			givenHeader:   Sign(testSecret, []byte(`{"id":"2"}`)),
+			expectedError: ErrInvalidSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(SignatureHeader, test.givenHeader)
+
+			err := (&HMACVerifier{Secret: testSecret}).Verify(req, body)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package notificationtest helps to test code receiving notifications of the notification package.
+package notificationtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/uuid"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/rhymond/interview-accountapi/notification"
+)
+
+// NewAccountEnvelope builds a sample account notification, useful in tests.
+func NewAccountEnvelope(eventType string, account *models.Account) (*notification.Envelope, error) {
+	data, err := json.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification.Envelope{
+		ID:             uuid.New().String(),
+		OrganisationID: account.OrganisationID,
+		EventType:      eventType,
+		RecordType:     notification.RecordTypeAccounts,
+		Version:        account.Version,
+		Data:           data,
+	}, nil
+}
+
+// Post delivers envelope to handler in process and returns recorded response.
+func Post(handler http.Handler, envelope *notification.Envelope, secret []byte) (*httptest.ResponseRecorder, error) {
+	req, err := notification.NewRequest("/", envelope, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder, nil
+}
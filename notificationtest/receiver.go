@@ -0,0 +1,59 @@
+package notificationtest
+
+import (
+	"context"
+	"net/http/httptest"
+
+	"github.com/rhymond/interview-accountapi/notification"
+)
+
+// Receiver is a local HTTP server receiving notifications, useful to verify delivery in tests.
+// Received notifications are verified and deduplicated like notification.Handler does and queued until
+// Next is called.
+type Receiver struct {
+	// URL is callback URI of the receiver.
+	URL string
+
+	server   *httptest.Server
+	received chan *notification.Envelope
+}
+
+// NewReceiver starts receiver accepting notifications of given record types. If secret is not nil,
+// notifications must be signed with it.
+func NewReceiver(secret []byte, recordTypes ...string) *Receiver {
+	var verifier notification.Verifier
+	if secret != nil {
+		verifier = &notification.HMACVerifier{Secret: secret}
+	}
+	handler := notification.NewHandler(verifier, notification.NewMemoryDeduplicator(100))
+
+	r := &Receiver{received: make(chan *notification.Envelope, 100)}
+	for _, recordType := range recordTypes {
+		handler.Handle(recordType, "", func(ctx context.Context, envelope *notification.Envelope) error {
+			select {
+			case r.received <- envelope:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}
+	r.server = httptest.NewServer(handler)
+	r.URL = r.server.URL
+	return r
+}
+
+// Next returns the next received notification, waiting for it until ctx is done.
+func (r *Receiver) Next(ctx context.Context) (*notification.Envelope, error) {
+	select {
+	case envelope := <-r.received:
+		return envelope, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the receiver.
+func (r *Receiver) Close() {
+	r.server.Close()
+}
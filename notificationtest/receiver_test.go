@@ -0,0 +1,72 @@
+package notificationtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/rhymond/interview-accountapi/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("secret")
+
+func TestReceiver(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenSecret        []byte
+		givenRecordType    string
+		expectedStatusCode int
+		expectedReceived   bool
+	}{
+		{
+			name:               "it should receive signed notification",
+			givenSecret:        testSecret,
+			givenRecordType:    notification.RecordTypeAccounts,
+			expectedStatusCode: http.StatusNoContent,
+			expectedReceived:   true,
+		},
+		{
+			name:               "it should reject notification with invalid signature",
+			givenSecret:        []byte("other"),
+			givenRecordType:    notification.RecordTypeAccounts,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "it should drop notification of other record type",
+			givenSecret:        testSecret,
+			givenRecordType:    "payments",
+			expectedStatusCode: http.StatusNoContent,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			receiver := NewReceiver(testSecret, notification.RecordTypeAccounts)
+			defer receiver.Close()
+
+			envelope, err := NewAccountEnvelope(notification.EventCreated, &models.Account{ID: "account-id", Attributes: models.AccountAttributes{Country: "GB"}})
+			require.Nil(t, err)
+			envelope.RecordType = test.givenRecordType
+			req, err := notification.NewRequest(receiver.URL, envelope, test.givenSecret)
+			require.Nil(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.Nil(t, err)
+			resp.Body.Close()
+			assert.Equal(t, test.expectedStatusCode, resp.StatusCode)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			received, err := receiver.Next(ctx)
+			if !test.expectedReceived {
+				assert.Equal(t, context.DeadlineExceeded, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, envelope.ID, received.ID)
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// Package reconcile compares expected accounts, e.g. from an internal ledger, with accounts held by the account API.
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/rhymond/interview-accountapi/client"
+	"github.com/rhymond/interview-accountapi/importer"
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// Lister pages through accounts. It is implemented by client.AccountService.
+type Lister interface {
+	ListAll(ctx context.Context, opts *client.AccountListOptions, fn func(*models.Account) error) error
+}
+
+// Source yields expected accounts one by one. It returns io.EOF when there are no more accounts.
+type Source interface {
+	Next() (*models.Account, error)
+}
+
+// MatchKey tells how expected and actual accounts were paired.
+type MatchKey string
+
+// Keys used to match accounts, in order of precedence.
+const (
+	MatchByID          MatchKey = "id"
+	MatchByBankAccount MatchKey = "bank_account"
+	MatchByIban        MatchKey = "iban"
+)
+
+// ignoredFields are not compared, as they are assigned by the API or are not account data.
+var ignoredFields = map[string]bool{
+	"type":          true,
+	"version":       true,
+	"relationships": true,
+}
+
+// Difference is a field which has different values in expected and actual account.
+// Fields are named as in the JSON API; missing values are nil.
+type Difference struct {
+	Field    string      `json:"field"`
+	Expected interface{} `json:"expected"`
+	Actual   interface{} `json:"actual"`
+}
+
+// Mismatch is a pair of matched accounts whose fields differ.
+type Mismatch struct {
+	MatchedBy   MatchKey       `json:"matched_by"`
+	Expected    models.Account `json:"expected"`
+	Actual      models.Account `json:"actual"`
+	Differences []Difference   `json:"differences"`
+}
+
+// Report is the result of reconciliation.
+type Report struct {
+	// Matched counts accounts found in both sources, including mismatched ones.
+	Matched int `json:"matched"`
+	// Missing accounts are expected, but not held by the API.
+	Missing []models.Account `json:"missing"`
+	// Extra accounts are held by the API, but not expected.
+	Extra      []models.Account `json:"extra"`
+	Mismatches []Mismatch       `json:"mismatches"`
+}
+
+// Clean reports whether both sources hold the same accounts.
+func (r *Report) Clean() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatches) == 0
+}
+
+// Reconcile reads all expected accounts from source, pages through all accounts matching opts
+// and pairs them by ID first. Accounts left unpaired are then paired by bank ID and account number,
+// then by IBAN, so a fallback match never takes an expected account which is paired by ID.
+func Reconcile(ctx context.Context, source Source, lister Lister, opts *client.AccountListOptions) (*Report, error) {
+	idx, err := newIndex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var actuals []models.Account
+	err = lister.ListAll(ctx, opts, func(actual *models.Account) error {
+		actuals = append(actuals, *actual)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make([]*models.Account, len(actuals))
+	keys := make([]MatchKey, len(actuals))
+	for _, pass := range [][]MatchKey{{MatchByID}, {MatchByBankAccount, MatchByIban}} {
+		for i := range actuals {
+			if expected[i] == nil {
+				expected[i], keys[i] = idx.match(&actuals[i], pass)
+			}
+		}
+	}
+
+	report := &Report{Missing: []models.Account{}, Extra: []models.Account{}, Mismatches: []Mismatch{}}
+	for i := range actuals {
+		actual := &actuals[i]
+		if expected[i] == nil {
+			report.Extra = append(report.Extra, *actual)
+			continue
+		}
+
+		report.Matched++
+		diffs, err := Compare(expected[i], actual)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) > 0 {
+			report.Mismatches = append(report.Mismatches, Mismatch{
+				MatchedBy:   keys[i],
+				Expected:    *expected[i],
+				Actual:      *actual,
+				Differences: diffs,
+			})
+		}
+	}
+
+	report.Missing = idx.unmatched()
+	return report, nil
+}
+
+// FromAccounts returns Source yielding given accounts.
+func FromAccounts(accounts []models.Account) Source {
+	return &sliceSource{accounts: accounts}
+}
+
+type sliceSource struct {
+	accounts []models.Account
+}
+
+func (s *sliceSource) Next() (*models.Account, error) {
+	if len(s.accounts) == 0 {
+		return nil, io.EOF
+	}
+	acc := s.accounts[0]
+	s.accounts = s.accounts[1:]
+	return &acc, nil
+}
+
+// FromImport returns Source reading accounts using importer.Reader, so ledgers can be read
+// from the same CSV and JSON files as imports. Rows which cannot be read fail the reconciliation.
+func FromImport(reader importer.Reader) Source {
+	return &importSource{reader: reader}
+}
+
+type importSource struct {
+	reader importer.Reader
+}
+
+func (s *importSource) Next() (*models.Account, error) {
+	row, err := s.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if row.Err != nil {
+		return nil, fmt.Errorf("line %d: %v", row.Line, row.Err)
+	}
+	return &row.Account, nil
+}
+
+// index looks up expected accounts by match keys and tracks which of them were matched.
+type index struct {
+	accounts []models.Account
+	matched  []bool
+	byKey    map[MatchKey]map[string][]int
+}
+
+func newIndex(source Source) (*index, error) {
+	idx := &index{byKey: map[MatchKey]map[string][]int{
+		MatchByID:          {},
+		MatchByBankAccount: {},
+		MatchByIban:        {},
+	}}
+	for {
+		acc, err := source.Next()
+		if err == io.EOF {
+			return idx, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		i := len(idx.accounts)
+		idx.accounts = append(idx.accounts, *acc)
+		idx.matched = append(idx.matched, false)
+		for key, value := range matchValues(acc) {
+			idx.byKey[key][value] = append(idx.byKey[key][value], i)
+		}
+	}
+}
+
+// match returns the first unmatched expected account paired with actual one by given keys,
+// trying them in order.
+func (idx *index) match(actual *models.Account, keys []MatchKey) (*models.Account, MatchKey) {
+	values := matchValues(actual)
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		for _, i := range idx.byKey[key][value] {
+			if !idx.matched[i] {
+				idx.matched[i] = true
+				return &idx.accounts[i], key
+			}
+		}
+	}
+	return nil, ""
+}
+
+func (idx *index) unmatched() []models.Account {
+	accounts := []models.Account{}
+	for i, acc := range idx.accounts {
+		if !idx.matched[i] {
+			accounts = append(accounts, acc)
+		}
+	}
+	return accounts
+}
+
+// matchValues returns values of match keys which are set on account.
+func matchValues(acc *models.Account) map[MatchKey]string {
+	values := make(map[MatchKey]string)
+	if acc.ID != "" {
+		values[MatchByID] = acc.ID
+	}
+	if acc.Attributes.BankID != "" && acc.Attributes.AccountNumber != "" {
+		values[MatchByBankAccount] = acc.Attributes.BankID + "/" + acc.Attributes.AccountNumber
+	}
+	if acc.Attributes.Iban != "" {
+		values[MatchByIban] = acc.Attributes.Iban
+	}
+	return values
+}
+
+// Compare returns differences of fields set in expected account, ordered by field name. Fields which
+// are not set, e.g. ID and organisation ID of ledger rows, are not compared, neither are type, version
+// and relationships. Actual account without status is compared as confirmed.
+func Compare(expected, actual *models.Account) ([]Difference, error) {
+	want, err := fields(expected)
+	if err != nil {
+		return nil, err
+	}
+	effective := *actual
+	effective.Attributes.Status = actual.Attributes.Status.Effective()
+	got, err := fields(&effective)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []Difference
+	for name, value := range want {
+		if value == nil || value == "" {
+			continue
+		}
+		if !reflect.DeepEqual(value, got[name]) {
+			diffs = append(diffs, Difference{Field: name, Expected: value, Actual: got[name]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+	return diffs, nil
+}
+
+// fields flattens account and its attributes into a map keyed by JSON field names.
+func fields(acc *models.Account) (map[string]interface{}, error) {
+	data, err := json.Marshal(acc)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	flat := make(map[string]interface{})
+	for name, value := range raw {
+		if attrs, ok := value.(map[string]interface{}); ok && name == "attributes" {
+			for attr, v := range attrs {
+				if !ignoredFields[attr] {
+					flat[attr] = v
+				}
+			}
+			continue
+		}
+		if !ignoredFields[name] {
+			flat[name] = value
+		}
+	}
+	return flat, nil
+}
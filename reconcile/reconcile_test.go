@@ -0,0 +1,202 @@
+package reconcile
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/clienttest"
+	"github.com/rhymond/interview-accountapi/importer"
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func account(id, bankID, accountNumber, iban string) models.Account {
+	return models.Account{
+		ID:             id,
+		OrganisationID: "organisation-id",
+		Type:           "accounts",
+		Attributes: models.AccountAttributes{
+			Country:       "GB",
+			BankID:        bankID,
+			AccountNumber: accountNumber,
+			Iban:          iban,
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	mismatched := account("d", "400302", "4", "")
+	mismatched.Attributes.Bic = "NWBKGB22"
+	mismatched.Version = 3
+	closed := account("d", "400302", "4", "")
+	closed.Attributes.Bic = "NWBKGB33"
+	closed.Attributes.Status = models.AccountClosed
+
+	expectedAccounts := []models.Account{
+		account("a", "400302", "1", ""),
+		account("ledger-b", "400302", "2", ""),
+		account("ledger-c", "", "", "GB33BUKB20201555555555"),
+		closed,
+		account("missing", "400302", "5", ""),
+	}
+	api := clienttest.NewFake(
+		account("a", "400302", "1", ""),
+		account("b", "400302", "2", ""),
+		account("c", "", "", "GB33BUKB20201555555555"),
+		mismatched,
+		account("extra", "400302", "6", ""),
+	)
+
+	report, err := Reconcile(context.TODO(), FromAccounts(expectedAccounts), api, nil)
+	require.Nil(t, err)
+
+	assert.False(t, report.Clean())
+	assert.Equal(t, 4, report.Matched)
+	require.Len(t, report.Missing, 1)
+	assert.Equal(t, "missing", report.Missing[0].ID)
+	require.Len(t, report.Extra, 1)
+	assert.Equal(t, "extra", report.Extra[0].ID)
+
+	matchedBy := map[string]MatchKey{}
+	differences := map[string][]Difference{}
+	for _, m := range report.Mismatches {
+		matchedBy[m.Actual.ID] = m.MatchedBy
+		differences[m.Actual.ID] = m.Differences
+	}
+	assert.Equal(t, map[string]MatchKey{"b": MatchByBankAccount, "c": MatchByIban, "d": MatchByID}, matchedBy)
+	assert.Equal(t, []Difference{{Field: "id", Expected: "ledger-b", Actual: "b"}}, differences["b"])
+	assert.Equal(t, []Difference{
+		{Field: "bic", Expected: "NWBKGB33", Actual: "NWBKGB22"},
+		{Field: "status", Expected: "closed", Actual: "confirmed"},
+	}, differences["d"])
+}
+
+func TestReconcile_MatchByIDFirst(t *testing.T) {
+	api := clienttest.NewFake(
+		account("a", "400302", "1", ""),
+		account("b", "400302", "2", ""),
+	)
+
+	report, err := Reconcile(context.TODO(), FromAccounts([]models.Account{account("b", "400302", "1", "")}), api, nil)
+	require.Nil(t, err)
+
+	assert.Equal(t, 1, report.Matched)
+	assert.Empty(t, report.Missing)
+	require.Len(t, report.Extra, 1)
+	assert.Equal(t, "a", report.Extra[0].ID, "it should not pair account by bank account when another is paired by ID")
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, MatchByID, report.Mismatches[0].MatchedBy)
+	assert.Equal(t, []Difference{{Field: "account_number", Expected: "1", Actual: "2"}}, report.Mismatches[0].Differences)
+}
+
+func TestReconcile_Clean(t *testing.T) {
+	acc := account("a", "400302", "1", "")
+	live := acc
+	live.Relationships = &models.AccountRelationships{Organisation: models.NewRelationship("organisations", "organisation-id")}
+	live.Attributes.Status = models.AccountConfirmed
+	api := clienttest.NewFake(live)
+
+	report, err := Reconcile(context.TODO(), FromAccounts([]models.Account{acc}), api, nil)
+	require.Nil(t, err)
+	assert.True(t, report.Clean())
+	assert.Equal(t, 1, report.Matched)
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name                string
+		givenExpected       models.Account
+		givenActual         models.Account
+		expectedDifferences []Difference
+	}{
+		{
+			name:          "it should compare only fields set in expected account",
+			givenExpected: models.Account{Attributes: models.AccountAttributes{BankID: "400302", AccountNumber: "1"}},
+			givenActual:   account("a", "400302", "1", "GB33BUKB20201555555555"),
+		},
+		{
+			name:                "it should report different status reason",
+			givenExpected:       models.Account{Attributes: models.AccountAttributes{Status: models.AccountClosed, StatusReason: "customer request"}},
+			givenActual:         models.Account{Attributes: models.AccountAttributes{Status: models.AccountClosed}},
+			expectedDifferences: []Difference{{Field: "status_reason", Expected: "customer request", Actual: nil}},
+		},
+		{
+			name:                "it should compare account without status as confirmed",
+			givenExpected:       models.Account{Attributes: models.AccountAttributes{Status: models.AccountSwitched}},
+			givenActual:         models.Account{},
+			expectedDifferences: []Difference{{Field: "status", Expected: "switched", Actual: "confirmed"}},
+		},
+		{
+			name:          "it should match confirmed status of account without status",
+			givenExpected: models.Account{Attributes: models.AccountAttributes{Status: models.AccountConfirmed}},
+			givenActual:   models.Account{},
+		},
+		{
+			name: "it should report different switched account details",
+			givenExpected: models.Account{Attributes: models.AccountAttributes{
+				SwitchedAccount: &models.SwitchedAccount{AccountNumber: "10000004", BankID: "400302", BankIDCode: "GBDSC"},
+			}},
+			givenActual: models.Account{},
+			expectedDifferences: []Difference{{
+				Field:    "switched_account_details",
+				Expected: map[string]interface{}{"account_number": "10000004", "bank_id": "400302", "bank_id_code": "GBDSC"},
+				Actual:   nil,
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diffs, err := Compare(&test.givenExpected, &test.givenActual)
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedDifferences, diffs)
+		})
+	}
+}
+
+func TestFromImport(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenCSV      string
+		expectedError string
+	}{
+		{
+			name:     "it should read accounts from import reader",
+			givenCSV: "id,country,bank_id,account_number\na,GB,400302,1\n",
+		},
+		{
+			name:          "it should fail on invalid row",
+			givenCSV:      "id,joint_account\na,maybe\n",
+			expectedError: "line 1: ",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader, err := importer.NewCSVReader(strings.NewReader(test.givenCSV), nil)
+			require.Nil(t, err)
+
+			report, err := Reconcile(context.TODO(), FromImport(reader), clienttest.NewFake(account("a", "400302", "1", "")), nil)
+			if test.expectedError != "" {
+				require.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, 1, report.Matched)
+		})
+	}
+}
+
+func TestFromImport_Status(t *testing.T) {
+	reader, err := importer.NewCSVReader(strings.NewReader("bank_id,account_number,status,status_reason\n400302,1,closed,customer request\n"), nil)
+	require.Nil(t, err)
+
+	report, err := Reconcile(context.TODO(), FromImport(reader), clienttest.NewFake(account("a", "400302", "1", "")), nil)
+	require.Nil(t, err)
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, []Difference{
+		{Field: "status", Expected: "closed", Actual: "confirmed"},
+		{Field: "status_reason", Expected: "customer request", Actual: nil},
+	}, report.Mismatches[0].Differences, "it should compare status read from ledger without ID")
+}
@@ -0,0 +1,62 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/rhymond/interview-accountapi/models"
+)
+
+// WriteJSON writes report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes human readable summary followed by missing, extra and mismatched accounts.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "matched: %d, missing: %d, extra: %d, mismatched: %d\n",
+		r.Matched, len(r.Missing), len(r.Extra), len(r.Mismatches))
+
+	writeAccounts(tw, "missing", r.Missing)
+	writeAccounts(tw, "extra", r.Extra)
+	if len(r.Mismatches) > 0 {
+		fmt.Fprintf(tw, "\nmismatched (%d):\n", len(r.Mismatches))
+		fmt.Fprintln(tw, "  ID\tMATCHED BY\tFIELD\tEXPECTED\tACTUAL")
+		for _, m := range r.Mismatches {
+			for _, d := range m.Differences {
+				fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", m.Actual.ID, m.MatchedBy, d.Field, formatValue(d.Expected), formatValue(d.Actual))
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func writeAccounts(w io.Writer, title string, accounts []models.Account) {
+	if len(accounts) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s (%d):\n", title, len(accounts))
+	fmt.Fprintln(w, "  ID\tBANK ID\tACCOUNT NUMBER\tIBAN")
+	for _, acc := range accounts {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", acc.ID, acc.Attributes.BankID, acc.Attributes.AccountNumber, acc.Attributes.Iban)
+	}
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "-"
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
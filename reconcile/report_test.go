@@ -0,0 +1,52 @@
+package reconcile
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rhymond/interview-accountapi/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReport() *Report {
+	return &Report{
+		Matched: 2,
+		Missing: []models.Account{account("missing", "400302", "5", "")},
+		Extra:   []models.Account{},
+		Mismatches: []Mismatch{{
+			MatchedBy:   MatchByBankAccount,
+			Expected:    account("ledger-b", "400302", "2", ""),
+			Actual:      account("b", "400302", "2", ""),
+			Differences: []Difference{{Field: "id", Expected: "ledger-b", Actual: "b"}, {Field: "bic", Actual: "NWBKGB22"}},
+		}},
+	}
+}
+
+func TestReport_WriteText(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.Nil(t, testReport().WriteText(buf))
+	assert.Equal(t, "matched: 2, missing: 1, extra: 0, mismatched: 1\n"+
+		"\n"+
+		"missing (1):\n"+
+		"  ID       BANK ID  ACCOUNT NUMBER  IBAN\n"+
+		"  missing  400302   5               \n"+
+		"\n"+
+		"mismatched (1):\n"+
+		"  ID  MATCHED BY    FIELD  EXPECTED    ACTUAL\n"+
+		"  b   bank_account  id     \"ledger-b\"  \"b\"\n"+
+		"  b   bank_account  bic    -           \"NWBKGB22\"\n", buf.String())
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.Nil(t, testReport().WriteJSON(buf))
+
+	var decoded Report
+	require.Nil(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, 2, decoded.Matched)
+	assert.Equal(t, "missing", decoded.Missing[0].ID)
+	assert.Equal(t, MatchByBankAccount, decoded.Mismatches[0].MatchedBy)
+	assert.Equal(t, "b", decoded.Mismatches[0].Differences[0].Actual)
+}
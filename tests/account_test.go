@@ -7,33 +7,84 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/godog"
 	"github.com/DATA-DOG/godog/gherkin"
 	"github.com/google/uuid"
+	"github.com/rhymond/interview-accountapi/cassette"
 	"github.com/rhymond/interview-accountapi/client"
 	"github.com/rhymond/interview-accountapi/models"
 )
 
+// cassettesDir holds recorded interactions, one cassette per scenario.
+const cassettesDir = "cassettes"
+
 type apiFeature struct {
 	client         *client.Client
+	baseURL        *url.URL
+	cassetteMode   string
+	recorder       *cassette.Recorder
 	resp           *client.Response
 	listedAccounts []models.Account
 	fetchedAccount *models.Account
 	createdAccount *models.Account
 }
 
-func (a *apiFeature) reset(interface{}) {
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// cassettePath returns cassette file of given scenario. Line number keeps scenarios with the same name apart.
+func cassettePath(scenario interface{}) string {
+	sc, ok := scenario.(*gherkin.Scenario)
+	if !ok {
+		panic(fmt.Sprintf("cassettes are not supported for %T", scenario))
+	}
+	name := strings.Trim(nonWord.ReplaceAllString(strings.ToLower(sc.Name), "-"), "-")
+	return filepath.Join(cassettesDir, fmt.Sprintf("%03d-%s.json", sc.Location.Line, name))
+}
+
+// startCassette replaces API client with one recording or replaying interactions of given scenario.
+func (a *apiFeature) startCassette(scenario interface{}) {
+	mode, err := cassette.ParseMode(a.cassetteMode)
+	if err != nil {
+		panic(err)
+	}
+
+	a.recorder, err = cassette.New(cassettePath(scenario), mode, nil)
+	if err != nil {
+		panic(err)
+	}
+	a.client = client.NewClient(&http.Client{Transport: a.recorder}, a.baseURL)
+}
+
+func (a *apiFeature) stopCassette(interface{}, error) {
+	if a.recorder == nil {
+		return
+	}
+	if err := a.recorder.Stop(); err != nil {
+		panic(err)
+	}
+	a.recorder = nil
+}
+
+func (a *apiFeature) reset(scenario interface{}) {
 	a.resp = nil
 	a.listedAccounts = nil
 	a.fetchedAccount = nil
 	a.createdAccount = nil
 
+	if a.cassetteMode != "" {
+		a.startCassette(scenario)
+	}
+
 	accs, _, err := a.client.Account.List(context.TODO(), nil)
 	if err != nil {
 		panic(err)
@@ -219,9 +270,12 @@ func FeatureContext(s *godog.Suite) {
 		panic(err)
 	}
 
+	api.baseURL = u
 	api.client = client.NewClient(nil, u)
+	api.cassetteMode = os.Getenv("ACCOUNT_API_CASSETTES")
 
 	s.BeforeScenario(api.reset)
+	s.AfterScenario(api.stopCassette)
 	s.Step(`^I create (\d+) accounts$`, api.iCreateAccounts)
 	s.Step(`^I list accounts$`, api.iListAccounts)
 	s.Step(`^I list (\d+) accounts per page$`, api.iListAccountsPerPage)